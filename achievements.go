@@ -0,0 +1,328 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Achievement thresholds - Tuned so every achievement is reachable but rare
+const (
+	SpeedrunLimit      = 10 * time.Second // Hard-mode win time for "Lightning Round"
+	WinStreakTarget    = 10               // Consecutive wins for "Unstoppable"
+	MinBinarySearchLen = 2                // Guesses needed before binary search is "perfect"
+)
+
+/*
+Achievement defines an unlockable milestone.
+
+Each achievement carries its own evaluation function so new achievements can
+be added by appending to the achievements table without touching the
+evaluation loop.
+*/
+type Achievement struct {
+	ID          string // Stable identifier persisted in the save file
+	Name        string // Display name
+	Icon        string // Emoji shown next to the name
+	Description string // How to unlock it
+
+	// Unlocked reports whether the winner earned the achievement this session
+	Unlocked func(ctx achievementContext) bool
+}
+
+/*
+UnlockedAchievement records when a player earned an achievement.
+*/
+type UnlockedAchievement struct {
+	ID         string    `json:"id"`
+	UnlockedAt time.Time `json:"unlocked_at"`
+}
+
+/*
+achievementContext bundles everything an achievement may inspect.
+
+Fields:
+- state: The finished game, including its guess log
+- session: The session record built from the finished game
+- winnerGuesses: The winner's own turns, in order
+*/
+type achievementContext struct {
+	state         *GameState
+	session       GameSession
+	winnerGuesses []GuessRecord
+}
+
+// achievements lists every unlockable achievement in display order
+var achievements = []Achievement{
+	{
+		ID:          "first_win",
+		Name:        "First Victory",
+		Icon:        "🏅",
+		Description: "Win your first game",
+		Unlocked:    func(ctx achievementContext) bool { return true },
+	},
+	{
+		ID:          "first_guess",
+		Name:        "Sharpshooter",
+		Icon:        "🎯",
+		Description: "Win with your very first guess",
+		Unlocked: func(ctx achievementContext) bool {
+			return len(ctx.winnerGuesses) == 1
+		},
+	},
+	{
+		ID:          "hard_speedrun",
+		Name:        "Lightning Round",
+		Icon:        "⚡",
+		Description: fmt.Sprintf("Win on Hard in under %s", SpeedrunLimit),
+		Unlocked: func(ctx achievementContext) bool {
+			return ctx.session.Difficulty == "hard" && ctx.session.Duration < SpeedrunLimit
+		},
+	},
+	{
+		ID:          "win_streak",
+		Name:        "Unstoppable",
+		Icon:        "🔥",
+		Description: fmt.Sprintf("Win %d games in a row", WinStreakTarget),
+		Unlocked: func(ctx achievementContext) bool {
+			return currentWinStreak(ctx.state.GameHistory, ctx.session.Winner)+1 >= WinStreakTarget
+		},
+	},
+	{
+		ID:          "perfect_binary_search",
+		Name:        "Binary Master",
+		Icon:        "🧠",
+		Description: "Win with every guess splitting the remaining range in half",
+		Unlocked:    isPerfectBinarySearch,
+	},
+	{
+		ID:          "comeback",
+		Name:        "Comeback Kid",
+		Icon:        "💪",
+		Description: "Win after being way off while an opponent was very close",
+		Unlocked:    isComebackWin,
+	},
+}
+
+/*
+evaluateAchievements determines which achievements the winner unlocked.
+
+Already-unlocked achievements are skipped so each is only awarded once per
+player.
+
+Parameters:
+- gameState *GameState: Finished game with guess log and prior history
+
+Returns:
+- map[string][]UnlockedAchievement: Newly unlocked achievements by player
+*/
+func evaluateAchievements(gameState *GameState) map[string][]UnlockedAchievement {
+	session, completed := buildGameSession(gameState)
+	if !completed {
+		return nil
+	}
+
+	ctx := achievementContext{state: gameState, session: session}
+	for _, record := range gameState.GuessLog {
+		if record.Player == session.Winner {
+			ctx.winnerGuesses = append(ctx.winnerGuesses, record)
+		}
+	}
+
+	unlocked := make(map[string][]UnlockedAchievement)
+	for _, achievement := range achievements {
+		if hasAchievement(gameState.Achievements, session.Winner, achievement.ID) {
+			continue
+		}
+		if achievement.Unlocked(ctx) {
+			unlocked[session.Winner] = append(unlocked[session.Winner], UnlockedAchievement{
+				ID:         achievement.ID,
+				UnlockedAt: session.Timestamp,
+			})
+		}
+	}
+
+	return unlocked
+}
+
+/*
+hasAchievement reports whether a player already unlocked an achievement.
+*/
+func hasAchievement(unlocked map[string][]UnlockedAchievement, player, id string) bool {
+	for _, achievement := range unlocked[player] {
+		if achievement.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+findAchievement looks up an achievement definition by ID.
+
+Returns:
+- Achievement: The definition
+- bool: False for IDs no longer defined (e.g. from a newer save file)
+*/
+func findAchievement(id string) (Achievement, bool) {
+	for _, achievement := range achievements {
+		if achievement.ID == id {
+			return achievement, true
+		}
+	}
+	return Achievement{}, false
+}
+
+/*
+currentWinStreak counts the player's consecutive wins at the end of history.
+
+Only games the player took part in affect the streak.
+*/
+func currentWinStreak(history []GameSession, player string) int {
+	streak := 0
+	for i := len(history) - 1; i >= 0; i-- {
+		if !contains(sessionPlayers(history[i]), player) {
+			continue
+		}
+		if history[i].Winner != player {
+			break
+		}
+		streak++
+	}
+	return streak
+}
+
+/*
+isPerfectBinarySearch checks whether each of the winner's guesses was the
+midpoint of the range still consistent with all earlier hints.
+
+The feasible range is replayed from the whole guess log because hints from
+other players narrow the range for everyone. Either middle value counts when
+the range has an even number of candidates.
+*/
+func isPerfectBinarySearch(ctx achievementContext) bool {
+	if len(ctx.winnerGuesses) < MinBinarySearchLen {
+		return false
+	}
+
+	low, high := 1, ctx.state.MaxRange
+	for _, record := range ctx.state.GuessLog {
+		result := record.Result
+		if !result.Valid {
+			if record.Player == ctx.session.Winner {
+				return false // Wasted turns are not a perfect search
+			}
+			continue
+		}
+
+		if record.Player == ctx.session.Winner {
+			lowMid, highMid := (low+high)/2, (low+high+1)/2
+			if result.Value != lowMid && result.Value != highMid {
+				return false
+			}
+		}
+
+		switch {
+		case result.Value < ctx.state.Target:
+			low = max(low, result.Value+1)
+		case result.Value > ctx.state.Target:
+			high = min(high, result.Value-1)
+		}
+	}
+	return true
+}
+
+/*
+isComebackWin checks whether the winner came back from a losing position.
+
+A comeback means that at some point during the game an opponent's best guess
+was "Very close!" while the winner's best guess so far was still "Way off!",
+using the same proximity tiers as getProximityHint.
+*/
+func isComebackWin(ctx achievementContext) bool {
+	distance := func(value int) int {
+		if value > ctx.state.Target {
+			return value - ctx.state.Target
+		}
+		return ctx.state.Target - value
+	}
+
+	winnerBest, opponentBest := -1, -1
+	for _, record := range ctx.state.GuessLog {
+		if record.Result.Correct || !record.Result.Valid {
+			continue
+		}
+		d := distance(record.Result.Value)
+		if record.Player == ctx.session.Winner {
+			if winnerBest < 0 || d < winnerBest {
+				winnerBest = d
+			}
+		} else if opponentBest < 0 || d < opponentBest {
+			opponentBest = d
+		}
+
+		if winnerBest < 0 || opponentBest < 0 {
+			continue
+		}
+		if getProximityHint(opponentBest, ctx.state.MaxRange) == "Very close!" &&
+			getProximityHint(winnerBest, ctx.state.MaxRange) == "Way off!" {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+announceAchievements prints the achievements unlocked this session.
+
+Parameters:
+- unlocked map[string][]UnlockedAchievement: New achievements by player
+*/
+func announceAchievements(unlocked map[string][]UnlockedAchievement) {
+	if len(unlocked) == 0 {
+		return
+	}
+
+	fmt.Printf("\n%sAchievements Unlocked:%s\n", ColorCyan, ColorReset)
+	for player, earned := range unlocked {
+		for _, entry := range earned {
+			achievement, known := findAchievement(entry.ID)
+			if !known {
+				continue
+			}
+			fmt.Printf("  %s %s%s%s unlocked %s%s%s - %s\n",
+				achievement.Icon, ColorGreen, player, ColorReset,
+				ColorYellow, achievement.Name, ColorReset, achievement.Description)
+		}
+	}
+}
+
+/*
+displayAchievements lists every player's unlocked achievements in the
+statistics dashboard.
+
+Parameters:
+- unlocked map[string][]UnlockedAchievement: All achievements by player
+*/
+func displayAchievements(unlocked map[string][]UnlockedAchievement) {
+	if len(unlocked) == 0 {
+		return
+	}
+
+	players := make([]string, 0, len(unlocked))
+	for player := range unlocked {
+		players = append(players, player)
+	}
+	sort.Strings(players)
+
+	fmt.Printf("\n%s Achievements:%s\n", ColorPurple, ColorReset)
+	for _, player := range players {
+		fmt.Printf("  %s%s%s (%d/%d):", ColorBlue, player, ColorReset, len(unlocked[player]), len(achievements))
+		for _, entry := range unlocked[player] {
+			if achievement, known := findAchievement(entry.ID); known {
+				fmt.Printf(" %s %s", achievement.Icon, achievement.Name)
+			}
+		}
+		fmt.Println()
+	}
+}
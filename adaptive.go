@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Adaptive difficulty constants - An average player gets the medium range
+// and default time limit; the range doubles or halves with every half point
+// of skill above or below average
+const (
+	DifficultyAdaptive = "adaptive" // Difficulty whose range and time limit follow the players' history
+
+	AdaptiveWindow   = 10  // Recent games per player considered
+	AdaptiveMinRange = 20  // Smallest range adaptive play will pick
+	AdaptiveMaxRange = 500 // Largest range adaptive play will pick
+	AdaptiveMinTime  = 5 * time.Second
+	AdaptiveMaxTime  = 20 * time.Second
+)
+
+/*
+AdaptiveParams are the game settings adaptive difficulty picked, with the
+skill estimate they were derived from.
+*/
+type AdaptiveParams struct {
+	Skill     float64       // 1 is an average player, higher is stronger
+	Games     int           // Recent games the estimate is based on
+	MaxRange  int           // Upper bound of the range
+	TimeLimit time.Duration // Time allowed per guess
+}
+
+/*
+playerSkill estimates a player's strength from their most recent games.
+
+Two signals are blended, each 1 for an average player:
+  - Win share: games won relative to a fair share (1/players) of each game
+  - Pace: how close the player's own turns came to bisection, log2(range)
+    turns, in the games they won
+
+Parameters:
+- history []GameSession: Complete session history, oldest first
+- player string: Player to rate
+
+Returns:
+- float64: Skill estimate (1 without history)
+- int: Number of games the estimate is based on
+*/
+func playerSkill(history []GameSession, player string) (float64, int) {
+	games, wins, fairShare, pace, paced := 0, 0, 0.0, 0.0, 0
+	for i := len(history) - 1; i >= 0 && games < AdaptiveWindow; i-- {
+		session := history[i]
+		if !contains(sessionPlayers(session), player) {
+			continue
+		}
+		games++
+		playerCount := max(session.PlayerCount, 1)
+		fairShare += 1 / float64(playerCount)
+		if session.Winner != player {
+			continue
+		}
+		wins++
+
+		maxRange := session.MaxRange
+		if maxRange == 0 {
+			maxRange = getMaxRange(session.Difficulty) // Recorded before ranges were stored
+		}
+		ownTurns := (session.Attempts + playerCount - 1) / playerCount
+		if ownTurns > 0 {
+			pace += math.Log2(float64(maxRange)) / float64(ownTurns)
+			paced++
+		}
+	}
+	if games == 0 {
+		return 1, 0
+	}
+
+	skill := float64(wins) / fairShare
+	if paced > 0 {
+		skill = (skill + pace/float64(paced)) / 2
+	}
+	return skill, games
+}
+
+/*
+chooseAdaptiveParams picks a range and time limit for the human players of a
+game from their recent history.
+
+The players' average skill sets the range on a logarithmic scale around the
+medium range, and the time limit shrinks as skill grows. Computer players
+are ignored; a game with no history plays like medium.
+
+Parameters:
+- history []GameSession: Complete session history
+- players []string: Everyone in the game
+- bots map[string]Bot: Computer players among them
+
+Returns:
+- AdaptiveParams: Settings for the game
+*/
+func chooseAdaptiveParams(history []GameSession, players []string, bots map[string]Bot) AdaptiveParams {
+	total, humans, games := 0.0, 0, 0
+	for _, player := range players {
+		if bots[player] != nil {
+			continue
+		}
+		skill, played := playerSkill(history, player)
+		total += skill
+		humans++
+		games += played
+	}
+	skill := 1.0
+	if humans > 0 {
+		skill = total / float64(humans)
+	}
+
+	maxRange := int(math.Round(float64(MediumMaxRange) * math.Pow(4, skill-1)))
+	timeLimit := time.Duration(float64(DefaultTimeLimit) / math.Max(skill, 0.1)).Round(time.Second)
+	if timeLimit < AdaptiveMinTime {
+		timeLimit = AdaptiveMinTime
+	} else if timeLimit > AdaptiveMaxTime {
+		timeLimit = AdaptiveMaxTime
+	}
+	return AdaptiveParams{
+		Skill:     skill,
+		Games:     games,
+		MaxRange:  max(AdaptiveMinRange, min(AdaptiveMaxRange, maxRange)),
+		TimeLimit: timeLimit,
+	}
+}
+
+/*
+applyAdaptiveDifficulty configures an adaptive game from the players'
+history and draws its target.
+*/
+func applyAdaptiveDifficulty(gameState *GameState) AdaptiveParams {
+	params := chooseAdaptiveParams(gameState.GameHistory, gameState.Players, gameState.Bots)
+	gameState.MaxRange = params.MaxRange
+	gameState.TimeLimit = params.TimeLimit
+	gameState.Target = rand.Intn(params.MaxRange) + 1
+	return params
+}
+
+/*
+scoringDifficulty returns the difficulty whose multiplier scores a game.
+Adaptive games score like the fixed difficulty closest in range size, so a
+veteran's wide adaptive range pays like hard and a small one like easy.
+*/
+func scoringDifficulty(gameState *GameState) string {
+	if gameState.Difficulty != DifficultyAdaptive {
+		return gameState.Difficulty
+	}
+	return nearestDifficulty(gameState.MaxRange)
+}
+
+// nearestDifficulty returns the fixed difficulty closest in range size, on a log scale
+func nearestDifficulty(maxRange int) string {
+	closest, distance := Difficulties[0], math.Inf(1)
+	for _, difficulty := range Difficulties {
+		d := math.Abs(math.Log2(float64(maxRange) / float64(getMaxRange(difficulty))))
+		if d < distance {
+			closest, distance = difficulty, d
+		}
+	}
+	return closest
+}
+
+// historyDifficulties lists every difficulty a saved session can have, in display order
+func historyDifficulties() []string {
+	return append(slices.Clip(Difficulties), DifficultyAdaptive)
+}
+
+/*
+recordDifficulty returns the record book key for a session. Adaptive ranges
+vary from game to game, so adaptive records are kept apart per scoring
+difficulty - "adaptive/hard" for ranges closest to hard - rather than
+comparing a 1-20 win with a 1-500 one.
+*/
+func recordDifficulty(session GameSession) string {
+	if session.Difficulty != DifficultyAdaptive || session.MaxRange == 0 {
+		return session.Difficulty
+	}
+	return DifficultyAdaptive + "/" + nearestDifficulty(session.MaxRange)
+}
+
+// recordDifficulties lists every record book key in display order
+func recordDifficulties() []string {
+	keys := slices.Clone(Difficulties)
+	for _, difficulty := range Difficulties {
+		keys = append(keys, DifficultyAdaptive+"/"+difficulty)
+	}
+	return keys
+}
+
+// difficultyTitle formats a difficulty or record book key for headings, e.g. "Adaptive (Hard range)"
+func difficultyTitle(key string) string {
+	if base, bucket, adaptive := strings.Cut(key, "/"); adaptive {
+		return fmt.Sprintf("%s (%s range)", strings.Title(base), strings.Title(bucket))
+	}
+	return strings.Title(key)
+}
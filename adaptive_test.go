@@ -0,0 +1,46 @@
+package main
+
+import "testing"
+
+func TestNearestDifficulty(t *testing.T) {
+	tests := []struct {
+		maxRange int
+		want     string
+	}{
+		{1, "easy"},
+		{EasyMaxRange, "easy"},
+		{70, "easy"}, // Closer to 50 than to 100 on a log scale
+		{71, "medium"},
+		{MediumMaxRange, "medium"},
+		{141, "medium"},
+		{142, "hard"},
+		{HardMaxRange, "hard"},
+		{5000, "hard"},
+	}
+	for _, tt := range tests {
+		if got := nearestDifficulty(tt.maxRange); got != tt.want {
+			t.Errorf("nearestDifficulty(%d) = %q, want %q", tt.maxRange, got, tt.want)
+		}
+	}
+}
+
+func TestRecordDifficulty(t *testing.T) {
+	tests := []struct {
+		name    string
+		session GameSession
+		want    string
+	}{
+		{"fixed difficulty", GameSession{Difficulty: "medium", MaxRange: MediumMaxRange}, "medium"},
+		{"fixed difficulty saved without a range", GameSession{Difficulty: "hard"}, "hard"},
+		{"small adaptive range", GameSession{Difficulty: DifficultyAdaptive, MaxRange: 30}, "adaptive/easy"},
+		{"wide adaptive range", GameSession{Difficulty: DifficultyAdaptive, MaxRange: 400}, "adaptive/hard"},
+		{"adaptive saved without a range", GameSession{Difficulty: DifficultyAdaptive}, DifficultyAdaptive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := recordDifficulty(tt.session); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
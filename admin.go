@@ -0,0 +1,421 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"gaming/my-guessing-game/protocol"
+)
+
+// Admin console constants
+const (
+	DefaultAdminAddr = "127.0.0.1:7780"            // Suggested admin listen address (loopback only)
+	AdminTokenEnv    = "GUESSING_GAME_ADMIN_TOKEN" // Environment variable holding the admin token
+	adminTimeout     = 10 * time.Second            // Request timeout of the "admin" command
+)
+
+/*
+adminServer lets the server operator manage a running lobby over HTTP.
+
+Every request must carry the admin token as a bearer token. The admin API
+runs on its own listener, normally bound to the loopback interface, so it
+is never exposed alongside the public API by accident.
+
+Routes:
+
+	GET  /admin/rooms                   List every room, started and finished ones included
+	GET  /admin/rooms/{id}/state        Dump the room's complete game state, target included
+	POST /admin/rooms/{id}/kick         Remove a player {player}
+	POST /admin/rooms/{id}/end          Abort the game and close the room
+	POST /admin/rooms/{id}/time-limit   Change the time per turn {time_limit_ms}
+	POST /admin/broadcast               Announce {text} to every room, or to {room} only
+*/
+type adminServer struct {
+	lobby *Lobby
+	token string
+	mux   *http.ServeMux
+}
+
+/*
+adminRoomView is a room as listed by the admin API.
+*/
+type adminRoomView struct {
+	protocol.RoomInfo
+	Finished bool      `json:"finished"`
+	Created  time.Time `json:"created"`
+	Turn     int       `json:"turn,omitempty"`
+	Attempts int       `json:"attempts"`
+	Away     []string  `json:"away,omitempty"`
+}
+
+/*
+newAdminServer creates the admin handler.
+
+Parameters:
+- lobby *Lobby: Rooms to manage
+- token string: Bearer token every request must present
+
+Returns:
+- *adminServer: Server ready to be used as an http.Handler
+*/
+func newAdminServer(lobby *Lobby, token string) *adminServer {
+	s := &adminServer{lobby: lobby, token: token, mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("GET /admin/rooms", s.handleListRooms)
+	s.mux.HandleFunc("GET /admin/rooms/{id}/state", s.handleRoomState)
+	s.mux.HandleFunc("POST /admin/rooms/{id}/kick", s.handleKick)
+	s.mux.HandleFunc("POST /admin/rooms/{id}/end", s.handleEndGame)
+	s.mux.HandleFunc("POST /admin/rooms/{id}/time-limit", s.handleTimeLimit)
+	s.mux.HandleFunc("POST /admin/broadcast", s.handleBroadcast)
+	return s
+}
+
+// ServeHTTP implements http.Handler, rejecting requests without the admin token
+func (s *adminServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		reportSuspicious("", r.RemoteAddr, "admin request without a valid admin token")
+		writeAPIError(w, http.StatusUnauthorized, protocol.CodeInvalidSession, "missing or invalid admin token")
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+/*
+startAdminServer serves the admin API for a lobby in the background.
+
+The token comes from the -admin-token flag or the environment; if neither
+is set, a random token is generated and printed so only whoever can read
+the server's console can use it.
+
+Parameters:
+- addr string: Listen address; empty disables the admin API
+- token string: Admin token, or empty to generate one
+- lobby *Lobby: Rooms to manage
+
+Returns:
+- func(): Shuts the admin server down
+- error: Failure to listen on addr
+*/
+func startAdminServer(addr, token string, lobby *Lobby) (func(), error) {
+	if addr == "" {
+		return func() {}, nil
+	}
+	generated := token == ""
+	if generated {
+		token = newToken(16)
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           newAdminServer(lobby, token),
+		ReadHeaderTimeout: JoinTimeout,
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	go server.Serve(listener)
+
+	fmt.Printf("%sAdmin API:%s http://%s/admin/\n", ColorBlue, ColorReset, listener.Addr())
+	if generated {
+		fmt.Printf("%sAdmin token:%s %s\n", ColorBlue, ColorReset, token)
+	}
+	return func() { server.Close() }, nil
+}
+
+/*
+handleListRooms lists every room with its progress.
+*/
+func (s *adminServer) handleListRooms(w http.ResponseWriter, r *http.Request) {
+	rooms := s.lobby.Rooms()
+	views := make([]adminRoomView, 0, len(rooms))
+	for _, room := range rooms {
+		snapshot := room.Match.Snapshot()
+		info := room.Info()
+		info.TimeLimitMS = snapshot.TimeLimitMS // Same snapshot as the progress below
+		views = append(views, adminRoomView{
+			RoomInfo: info,
+			Finished: snapshot.Finished,
+			Created:  room.Created,
+			Turn:     snapshot.Turn,
+			Attempts: snapshot.Attempts,
+			Away:     snapshot.Away,
+		})
+	}
+	writeJSON(w, http.StatusOK, views)
+}
+
+/*
+handleRoomState dumps a room's complete game state for debugging.
+*/
+func (s *adminServer) handleRoomState(w http.ResponseWriter, r *http.Request) {
+	room, err := s.lobby.Room(r.PathValue("id"))
+	if err != nil {
+		writeMatchError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, room.Match.Dump())
+}
+
+/*
+handleKick removes a player from a room.
+
+Request Body:
+
+	{"player": "Alice"}
+*/
+func (s *adminServer) handleKick(w http.ResponseWriter, r *http.Request) {
+	room, err := s.lobby.Room(r.PathValue("id"))
+	if err != nil {
+		writeMatchError(w, err)
+		return
+	}
+	var body struct {
+		Player string `json:"player"`
+	}
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	player := strings.TrimSpace(body.Player)
+	if err := room.Kick(player); err != nil {
+		writeMatchError(w, err)
+		return
+	}
+	room.Match.Announce(fmt.Sprintf("%s was removed by the server operator.", player))
+	printColoredMessage(fmt.Sprintf("[%s] Admin kicked %s", room.Code, player), ColorYellow)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+/*
+handleEndGame aborts a room's game and closes the room.
+*/
+func (s *adminServer) handleEndGame(w http.ResponseWriter, r *http.Request) {
+	room, err := s.lobby.Room(r.PathValue("id"))
+	if err != nil {
+		writeMatchError(w, err)
+		return
+	}
+	room.Match.Announce("The server operator ended this game.")
+	s.lobby.remove(room)
+	printColoredMessage(fmt.Sprintf("[%s] Admin ended the game", room.Code), ColorYellow)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+/*
+handleTimeLimit changes a room's time per turn from the next turn on.
+
+Request Body:
+
+	{"time_limit_ms": 15000}
+*/
+func (s *adminServer) handleTimeLimit(w http.ResponseWriter, r *http.Request) {
+	room, err := s.lobby.Room(r.PathValue("id"))
+	if err != nil {
+		writeMatchError(w, err)
+		return
+	}
+	var body struct {
+		TimeLimitMS int64 `json:"time_limit_ms"`
+	}
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	limit := time.Duration(body.TimeLimitMS) * time.Millisecond
+	if limit <= 0 {
+		writeAPIError(w, http.StatusBadRequest, protocol.CodeMalformed, "time limit must be positive")
+		return
+	}
+	if err := room.Match.SetTimeLimit(limit); err != nil {
+		writeMatchError(w, err)
+		return
+	}
+	room.Match.Announce(fmt.Sprintf("Time per guess is now %s, starting next turn.", limit))
+	printColoredMessage(fmt.Sprintf("[%s] Admin set the time limit to %s", room.Code, limit), ColorYellow)
+	writeJSON(w, http.StatusOK, roomView(room))
+}
+
+/*
+handleBroadcast announces a message to one room or to every room in play.
+
+Request Body:
+
+	{"text": "Server restarts in 5 minutes", "room": "K7QX2M"}
+*/
+func (s *adminServer) handleBroadcast(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Text string `json:"text"`
+		Room string `json:"room"`
+	}
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	text := sanitizeChat(body.Text)
+	if text == "" {
+		writeAPIError(w, http.StatusBadRequest, protocol.CodeMalformed, "text is required")
+		return
+	}
+
+	rooms := s.lobby.Rooms()
+	if body.Room != "" {
+		room, err := s.lobby.Room(body.Room)
+		if err != nil {
+			writeMatchError(w, err)
+			return
+		}
+		rooms = []*Room{room}
+	}
+
+	delivered := 0
+	for _, room := range rooms {
+		if room.Match.Announce(text) == nil {
+			delivered++
+		}
+	}
+	printColoredMessage(fmt.Sprintf("Admin announcement to %d room(s): %s", delivered, text), ColorPurple)
+	writeJSON(w, http.StatusOK, map[string]int{"rooms": delivered})
+}
+
+/*
+runAdminCommand talks to the admin API of a running server.
+
+Usage:
+
+	guessing-game admin [-addr 127.0.0.1:7780] [-token T] rooms
+	guessing-game admin state CODE
+	guessing-game admin kick CODE PLAYER
+	guessing-game admin end CODE
+	guessing-game admin time-limit CODE 15s
+	guessing-game admin [-room CODE] broadcast TEXT...
+
+The token defaults to the GUESSING_GAME_ADMIN_TOKEN environment variable.
+Responses are printed as indented JSON.
+
+Parameters:
+- args []string: Subcommand arguments
+
+Returns:
+- int: Process exit code
+*/
+func runAdminCommand(args []string) int {
+	flags := flag.NewFlagSet("admin", flag.ContinueOnError)
+	addr := flags.String("addr", DefaultAdminAddr, "admin API address of the server")
+	token := flags.String("token", os.Getenv(AdminTokenEnv), "admin token (default $"+AdminTokenEnv+")")
+	room := flags.String("room", "", "room for broadcast (default: every room)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	words := flags.Args()
+	usage := func() int {
+		printColoredMessage("Usage: guessing-game admin rooms | state CODE | kick CODE PLAYER | end CODE | time-limit CODE 15s | broadcast TEXT...", ColorRed)
+		return 2
+	}
+	if len(words) == 0 {
+		return usage()
+	}
+
+	var method, path string
+	var body any
+	switch {
+	case words[0] == "rooms" && len(words) == 1:
+		method, path = http.MethodGet, "/admin/rooms"
+	case words[0] == "state" && len(words) == 2:
+		method, path = http.MethodGet, "/admin/rooms/"+words[1]+"/state"
+	case words[0] == "kick" && len(words) == 3:
+		method, path = http.MethodPost, "/admin/rooms/"+words[1]+"/kick"
+		body = map[string]string{"player": words[2]}
+	case words[0] == "end" && len(words) == 2:
+		method, path = http.MethodPost, "/admin/rooms/"+words[1]+"/end"
+	case words[0] == "time-limit" && len(words) == 3:
+		limit, err := time.ParseDuration(words[2])
+		if err != nil {
+			printColoredMessage(fmt.Sprintf("Invalid time limit %q: %v", words[2], err), ColorRed)
+			return 2
+		}
+		method, path = http.MethodPost, "/admin/rooms/"+words[1]+"/time-limit"
+		body = map[string]int64{"time_limit_ms": limit.Milliseconds()}
+	case words[0] == "broadcast" && len(words) > 1:
+		method, path = http.MethodPost, "/admin/broadcast"
+		body = map[string]string{"text": strings.Join(words[1:], " "), "room": *room}
+	default:
+		return usage()
+	}
+
+	response, err := adminRequest(*addr, *token, method, path, body)
+	if err != nil {
+		printColoredMessage(fmt.Sprintf("Admin request failed: %v", err), ColorRed)
+		return 1
+	}
+	if len(response) > 0 {
+		var pretty bytes.Buffer
+		if json.Indent(&pretty, response, "", "  ") == nil {
+			response = pretty.Bytes()
+		}
+		fmt.Println(strings.TrimSpace(string(response)))
+	} else {
+		printColoredMessage("Done.", ColorGreen)
+	}
+	return 0
+}
+
+/*
+adminRequest sends one request to the admin API.
+
+Returns:
+- []byte: Response body of a successful request
+- error: Transport failure, or the server's error message
+*/
+func adminRequest(addr, token, method, path string, body any) ([]byte, error) {
+	var payload io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		payload = bytes.NewReader(encoded)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), adminTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, method, "http://"+addr+path, payload)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode >= 300 {
+		var apiErr apiError
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error != "" {
+			return nil, errors.New(apiErr.Error)
+		}
+		return nil, errors.New(response.Status)
+	}
+	return data, nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Anti-cheat constants - A human playing (and chatting) never comes close to
+// these limits; a script flooding guesses or probing the server does
+const (
+	MessageBurst  = 10                     // Messages a connection may send in a row
+	MessageRefill = 250 * time.Millisecond // Time to earn back one message
+	MaxStrikes    = 20                     // Suspicious messages before a connection is dropped
+
+	RequestBurst      = 20                     // POST requests an HTTP client may send in a row
+	RequestRefill     = 250 * time.Millisecond // Time to earn back one request
+	requestLimiterTTL = 10 * time.Minute       // Idle time after which a client's limiter is forgotten
+)
+
+/*
+connGuard polices a single network connection.
+
+Every message is charged against a per-connection rate limit, and every
+message the server has to refuse for a reason an honest client would not
+trigger - flooding, malformed lines, guesses without a turn number, guessing
+out of turn or twice in one turn, spectators trying to play - counts as a
+strike and is logged on the host's console. A connection that has collected
+MaxStrikes strikes is Exhausted and gets dropped.
+
+The target is never part of this picture: it only leaves the server in
+game_over, or in spectator views whose host chose to reveal it.
+*/
+type connGuard struct {
+	scope   string // Log prefix, e.g. "[K7QX2M] " or ""
+	who     string // Player name or remote address
+	limiter *rateLimiter
+	strikes int
+}
+
+/*
+newConnGuard creates a guard for a freshly accepted connection.
+
+Parameters:
+- who string: Remote address until the connection joins a room
+
+Returns:
+- *connGuard: Guard with a full rate limit allowance
+*/
+func newConnGuard(who string) *connGuard {
+	return &connGuard{who: who, limiter: newRateLimiter(MessageBurst, MessageRefill)}
+}
+
+/*
+Identify attaches the room and player to later log lines.
+*/
+func (g *connGuard) Identify(room, who string) {
+	g.scope = "[" + room + "] "
+	g.who = who
+}
+
+/*
+Allow charges one message against the rate limit.
+
+Returns:
+- bool: False if the message must be refused; a strike has been recorded
+*/
+func (g *connGuard) Allow() bool {
+	if g.limiter.Allow() {
+		return true
+	}
+	g.Strike("flooding messages")
+	return false
+}
+
+/*
+Strike records and logs suspicious behavior.
+
+Parameters:
+- reason string: What the client did
+*/
+func (g *connGuard) Strike(reason string) {
+	g.strikes++
+	reportSuspicious(g.scope, g.who, fmt.Sprintf("%s (strike %d/%d)", reason, g.strikes, MaxStrikes))
+}
+
+/*
+Exhausted reports whether the connection has run out of strikes and should
+be closed.
+*/
+func (g *connGuard) Exhausted() bool {
+	return g.strikes >= MaxStrikes
+}
+
+/*
+reportSuspicious logs behavior that suggests a cheating or broken client.
+
+Parameters:
+- scope string: Log prefix naming the room, if any
+- who string: Player name or client address
+- reason string: What happened
+*/
+func reportSuspicious(scope, who, reason string) {
+	printColoredMessage(fmt.Sprintf("%sSuspicious activity from %s: %s", scope, who, reason), ColorYellow)
+}
+
+/*
+requestLimiter rate-limits HTTP clients by IP address.
+
+HTTP has no connection to attach a guard to, so state-changing requests are
+charged to the client's address instead.
+*/
+type requestLimiter struct {
+	mu       sync.Mutex
+	clients  map[string]*rateLimiter
+	lastSeen map[string]time.Time
+}
+
+/*
+newRequestLimiter creates an empty limiter.
+*/
+func newRequestLimiter() *requestLimiter {
+	return &requestLimiter{
+		clients:  make(map[string]*rateLimiter),
+		lastSeen: make(map[string]time.Time),
+	}
+}
+
+/*
+Allow charges one request to the client that sent r.
+
+Returns:
+- bool: False if the client is over its limit
+*/
+func (l *requestLimiter) Allow(r *http.Request) bool {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	now := time.Now()
+	l.mu.Lock()
+	for client, seen := range l.lastSeen {
+		if now.Sub(seen) > requestLimiterTTL {
+			delete(l.clients, client)
+			delete(l.lastSeen, client)
+		}
+	}
+	limiter := l.clients[ip]
+	if limiter == nil {
+		limiter = newRateLimiter(RequestBurst, RequestRefill)
+		l.clients[ip] = limiter
+	}
+	l.lastSeen[ip] = now
+	l.mu.Unlock()
+
+	if limiter.Allow() {
+		return true
+	}
+	reportSuspicious("", ip, fmt.Sprintf("flooding %s %s", r.Method, r.URL.Path))
+	return false
+}
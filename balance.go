@@ -0,0 +1,238 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Balance report defaults
+const (
+	DefaultBalanceGames = 20000 // Simulated solo games per difficulty and play style
+	BalanceTolerance    = 0.10  // Reward may deviate this much from effort and still count as proportional
+)
+
+// balanceProfiles are the play styles the balance report simulates
+var balanceProfiles = []struct {
+	Label    string
+	Strategy string
+}{
+	{"optimal", BotBinary}, // Perfect bisection, answering instantly
+	{"typical", BotHuman},  // Imprecise, forgetful and taking time to think
+}
+
+/*
+BalanceRow summarizes one play style at one difficulty.
+*/
+type BalanceRow struct {
+	Difficulty string
+	Profile    string
+	Attempts   float64       // Mean attempts to find the number
+	Elapsed    time.Duration // Mean game time
+	Scores     ScoreSummary
+}
+
+/*
+BalanceVerdict compares what a difficulty demands of typical players with
+what it pays them, both relative to easy.
+
+A difficulty is rewarded proportionally when Reward is within
+BalanceTolerance of Effort. Suggested is the multiplier that would make
+the two equal.
+*/
+type BalanceVerdict struct {
+	Difficulty string
+	Multiplier float64 // Current difficulty multiplier
+	Effort     float64 // Mean attempts relative to easy
+	Reward     float64 // Mean score relative to easy
+	Suggested  float64 // Multiplier making Reward equal Effort
+	Verdict    string  // "proportional", "over-rewarded" or "under-rewarded"
+}
+
+/*
+BalanceReport is the outcome of a scoring balance simulation.
+*/
+type BalanceReport struct {
+	Games    int
+	Seed     int64
+	Rows     []BalanceRow
+	Verdicts []BalanceVerdict
+	Premium  map[string]float64 // Difficulty -> how much more optimal play scores than typical play
+}
+
+/*
+buildBalanceReport simulates solo games of optimal and typical play at every
+difficulty and checks calculateScore against the results.
+
+Effort is measured in attempts because that is what grows with the range;
+reward is the mean score, including the difficulty multiplier. Both are
+taken from typical play, which is what most players experience.
+
+Parameters:
+- games int: Games per difficulty and play style
+- seed int64: Base seed for the simulations
+- workers int: Games played in parallel
+
+Returns:
+- *BalanceReport: Expected scores, spread and verdicts
+- error: Simulation failure
+*/
+func buildBalanceReport(games int, seed int64, workers int) (*BalanceReport, error) {
+	report := &BalanceReport{Games: games, Seed: seed, Premium: make(map[string]float64)}
+	means := make(map[string]map[string]float64) // Profile -> difficulty -> mean score
+	attempts := make(map[string]float64)         // Difficulty -> typical mean attempts
+
+	for _, profile := range balanceProfiles {
+		simulation, err := runSimulation(SimulationConfig{
+			Strategies:   []string{profile.Strategy},
+			Difficulties: Difficulties,
+			Games:        games,
+			Seed:         seed,
+			Workers:      workers,
+			TimeLimit:    DefaultTimeLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		means[profile.Label] = make(map[string]float64)
+		for _, difficulty := range Difficulties {
+			stats := simulation.Stats[difficulty][simulation.Players[0]]
+			row := BalanceRow{
+				Difficulty: difficulty,
+				Profile:    profile.Label,
+				Attempts:   stats.AverageAttempts(),
+				Elapsed:    stats.AverageElapsed(),
+				Scores:     summarizeScores(stats.Scores),
+			}
+			report.Rows = append(report.Rows, row)
+			means[profile.Label][difficulty] = row.Scores.Mean
+			if profile.Strategy == BotHuman {
+				attempts[difficulty] = row.Attempts
+			}
+		}
+	}
+
+	base := Difficulties[0]
+	for _, difficulty := range Difficulties {
+		if typical := means["typical"][difficulty]; typical > 0 {
+			report.Premium[difficulty] = means["optimal"][difficulty]/typical - 1
+		}
+		if difficulty == base || attempts[base] == 0 || means["typical"][base] == 0 {
+			continue
+		}
+
+		verdict := BalanceVerdict{
+			Difficulty: difficulty,
+			Multiplier: difficultyMultiplier(difficulty),
+			Effort:     attempts[difficulty] / attempts[base],
+			Reward:     means["typical"][difficulty] / means["typical"][base],
+		}
+		verdict.Suggested = verdict.Multiplier * verdict.Effort / verdict.Reward
+		switch {
+		case verdict.Reward > verdict.Effort*(1+BalanceTolerance):
+			verdict.Verdict = "over-rewarded"
+		case verdict.Reward < verdict.Effort*(1-BalanceTolerance):
+			verdict.Verdict = "under-rewarded"
+		default:
+			verdict.Verdict = "proportional"
+		}
+		report.Verdicts = append(report.Verdicts, verdict)
+	}
+	return report, nil
+}
+
+/*
+displayBalanceReport prints expected scores per difficulty and play style,
+then whether harder difficulties pay in proportion to the effort they take.
+*/
+func displayBalanceReport(report *BalanceReport) {
+	printColoredHeader("⚖️ Scoring Balance Report")
+	fmt.Printf("%sSimulation:%s %d solo games per difficulty and play style • seed %d\n",
+		ColorBlue, ColorReset, report.Games, report.Seed)
+	fmt.Printf("%sPlay styles:%s optimal = %s bot, typical = %s bot\n",
+		ColorBlue, ColorReset, BotBinary, BotHuman)
+
+	fmt.Printf("\n%s Expected Scores:%s\n", ColorCyan, ColorReset)
+	fmt.Printf("  %-10s %-8s %5s %9s %7s %7s %6s %9s %6s %6s\n",
+		"Difficulty", "Play", "Mult", "Attempts", "Time", "Mean", "SD", "Variance", "P10", "P90")
+	for _, row := range report.Rows {
+		fmt.Printf("  %-10s %-8s %4.1fx %9.2f %7s %7.0f %6.1f %9.0f %6d %6d\n",
+			strings.Title(row.Difficulty), row.Profile, difficultyMultiplier(row.Difficulty),
+			row.Attempts, row.Elapsed.Round(time.Second), row.Scores.Mean, row.Scores.StdDev,
+			row.Scores.StdDev*row.Scores.StdDev, row.Scores.P10, row.Scores.P90)
+	}
+
+	fmt.Printf("\n%s Proportionality (typical play, relative to %s):%s\n", ColorCyan, strings.Title(Difficulties[0]), ColorReset)
+	fmt.Printf("  %-10s %5s %7s %7s %-15s %s\n", "Difficulty", "Mult", "Effort", "Reward", "Verdict", "Suggested")
+	for _, verdict := range report.Verdicts {
+		color := ColorGreen
+		if verdict.Verdict != "proportional" {
+			color = ColorYellow
+		}
+		fmt.Printf("  %-10s %4.1fx %6.2fx %6.2fx %s%-15s%s %.2fx\n",
+			strings.Title(verdict.Difficulty), verdict.Multiplier, verdict.Effort, verdict.Reward,
+			color, verdict.Verdict, ColorReset, verdict.Suggested)
+	}
+
+	fmt.Printf("\n%s Skill Premium (optimal over typical play):%s\n", ColorCyan, ColorReset)
+	for _, difficulty := range Difficulties {
+		fmt.Printf("  %-10s %+.1f%%\n", strings.Title(difficulty), report.Premium[difficulty]*100)
+	}
+	if highest := maxPremium(report); highest < BalanceTolerance {
+		printColoredMessage(fmt.Sprintf("\nOptimal play scores at most %.1f%% more than typical play: the attempt and time penalties barely separate skill levels.",
+			highest*100), ColorYellow)
+	}
+	printSeparator()
+}
+
+// maxPremium returns the largest skill premium across difficulties
+func maxPremium(report *BalanceReport) float64 {
+	highest := math.Inf(-1)
+	for _, premium := range report.Premium {
+		highest = math.Max(highest, premium)
+	}
+	return highest
+}
+
+/*
+runBalanceCommand simulates play at every difficulty and reports whether the
+scoring rewards harder difficulties proportionally.
+
+Usage:
+
+	guessing-game balance [-games 20000] [-seed 42] [-workers 8]
+
+Parameters:
+- args []string: Subcommand arguments
+
+Returns:
+- int: Process exit code
+*/
+func runBalanceCommand(args []string) int {
+	flags := flag.NewFlagSet("balance", flag.ContinueOnError)
+	games := flags.Int("games", DefaultBalanceGames, "solo games per difficulty and play style")
+	seed := flags.Int64("seed", 0, "random seed (0 picks one)")
+	workers := flags.Int("workers", runtime.NumCPU(), "games played in parallel")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *games < 1 || *workers < 1 {
+		printColoredMessage("-games and -workers must be positive.", ColorRed)
+		return 2
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	report, err := buildBalanceReport(*games, *seed, *workers)
+	if err != nil {
+		printColoredMessage(err.Error(), ColorRed)
+		return 1
+	}
+	displayBalanceReport(report)
+	return 0
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bot strategy names accepted by newBot, in the order they are offered
+const (
+	BotRandom = "random" // Picks any number still in play
+	BotLinear = "linear" // Counts up from the bottom of the range
+	BotBinary = "binary" // Halves the range every turn - never needs more than log2(range)+1 guesses
+	BotHuman  = "human"  // Aims for the middle, but imprecisely and with thinking time
+)
+
+// BotStrategies lists every built-in strategy
+var BotStrategies = []string{BotRandom, BotLinear, BotBinary, BotHuman}
+
+// ErrUnknownBot is returned for strategy names newBot does not know
+var ErrUnknownBot = errors.New("unknown bot strategy")
+
+/*
+BotView is what a computer player knows when its turn starts: the public
+game configuration and the feasible range implied by every hint given so
+far, exactly as a human watching the game would know it. Bots never see
+the target.
+*/
+type BotView struct {
+	MaxRange  int           // Upper bound of the difficulty's range
+	TimeLimit time.Duration // Time allowed per guess
+	Low       int           // Smallest number not yet ruled out
+	High      int           // Largest number not yet ruled out
+	Attempts  int           // Turns taken so far by all players
+}
+
+/*
+Bot is a computer player.
+
+NextGuess picks a guess for the bot's turn and says how long the bot
+"thinks" in total before answering, including any time NextGuess itself
+took. Front ends wait out the rest of that time (a think time reaching the
+time limit is a timeout); headless simulations may skip the wait.
+*/
+type Bot interface {
+	NextGuess(view BotView) (guess int, think time.Duration)
+}
+
+/*
+newBot creates a built-in computer player.
+
+Parameters:
+- strategy string: One of BotStrategies
+- rng *rand.Rand: Source of randomness, so simulations can be seeded
+
+Returns:
+- Bot: The computer player
+- error: ErrUnknownBot
+*/
+func newBot(strategy string, rng *rand.Rand) (Bot, error) {
+	switch strategy {
+	case BotRandom:
+		return &randomBot{rng: rng}, nil
+	case BotLinear:
+		return linearBot{}, nil
+	case BotBinary:
+		return binaryBot{}, nil
+	case BotHuman:
+		return &humanBot{rng: rng}, nil
+	}
+	return nil, fmt.Errorf("%w %q (want %s)", ErrUnknownBot, strategy, strings.Join(BotStrategies, ", "))
+}
+
+/*
+botName suggests a unique display name for a bot, e.g. "BinaryBot" or
+"BinaryBot2" if that is taken.
+*/
+func botName(strategy string, taken []string) string {
+	base := strings.Title(strategy) + "Bot"
+	name := base
+	for n := 2; contains(taken, name); n++ {
+		name = base + strconv.Itoa(n)
+	}
+	return name
+}
+
+// randomBot guesses uniformly among the numbers still in play
+type randomBot struct {
+	rng *rand.Rand
+}
+
+func (b *randomBot) NextGuess(view BotView) (int, time.Duration) {
+	return view.Low + b.rng.Intn(view.High-view.Low+1), 0
+}
+
+// linearBot tries the smallest number still in play, one after another
+type linearBot struct{}
+
+func (linearBot) NextGuess(view BotView) (int, time.Duration) {
+	return view.Low, 0
+}
+
+// binaryBot bisects the feasible range, the optimal strategy
+type binaryBot struct{}
+
+func (binaryBot) NextGuess(view BotView) (int, time.Duration) {
+	return (view.Low + view.High) / 2, 0
+}
+
+/*
+humanBot plays like a reasonable person: it aims for the middle of the
+range but rounds to "nice" numbers and misjudges by a few percent, now and
+then forgets a hint, and takes a second or two to think - longer while the
+range is wide, and sometimes too long.
+*/
+type humanBot struct {
+	rng *rand.Rand
+}
+
+// Human-like bot tuning
+const (
+	humanAimError   = 0.15                    // Standard deviation of the aim, as a share of the range width
+	humanForgetRate = 0.1                     // Chance of ignoring the hints for a turn
+	humanThinkBase  = 800 * time.Millisecond  // Minimum thinking time
+	humanThinkSpan  = 1500 * time.Millisecond // Typical extra thinking time for a full range
+)
+
+func (b *humanBot) NextGuess(view BotView) (int, time.Duration) {
+	low, high := view.Low, view.High
+	if b.rng.Float64() < humanForgetRate {
+		low, high = 1, view.MaxRange // Lost track of the hints
+	}
+
+	width := float64(high - low + 1)
+	aim := float64(low+high)/2 + b.rng.NormFloat64()*humanAimError*width
+	if width > 20 {
+		aim = math.Round(aim/5) * 5 // People like round numbers
+	}
+	guess := int(math.Round(aim))
+	guess = max(low, min(high, guess))
+
+	// Think longer while there is more to consider; log-normal for the odd long pause
+	share := math.Log2(width+1) / math.Log2(float64(view.MaxRange)+1)
+	think := humanThinkBase + time.Duration(float64(humanThinkSpan)*share*math.Exp(b.rng.NormFloat64()*0.5))
+	return guess, think
+}
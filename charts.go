@@ -0,0 +1,259 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Chart rendering constants - Chosen so charts fit a standard 80-column
+// terminal and fall back to plain text when the terminal is too narrow
+const (
+	DefaultTerminalWidth = 80  // Assumed width when COLUMNS is unset
+	MinChartWidth        = 40  // Narrower terminals get the plain-text fallback
+	MaxChartWidth        = 100 // Wider terminals still get charts this wide
+	HistogramBuckets     = 6   // Maximum number of attempt buckets per difficulty
+
+	chartIndent = "  "
+	barGlyph    = "█"
+)
+
+// sparkLevels are the eight block heights used by sparklines, lowest first
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+/*
+terminalWidth determines how many columns are available for charts.
+
+The COLUMNS environment variable (exported by most shells) is honored when
+present; otherwise a standard 80-column terminal is assumed. The result is
+capped so charts stay readable on very wide screens.
+
+Returns:
+- int: Usable terminal width in columns
+*/
+func terminalWidth() int {
+	width := DefaultTerminalWidth
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		width = columns
+	}
+	return min(width, MaxChartWidth)
+}
+
+/*
+renderSparkline draws a series of values as a single line of block glyphs.
+
+When there are more values than columns, only the most recent values that
+fit are drawn so the line always ends with the latest game.
+
+Parameters:
+- values []int: Series in chronological order
+- width int: Maximum number of glyphs
+
+Returns:
+- string: Sparkline (empty when there is nothing to draw)
+*/
+func renderSparkline(values []int, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	low, high := values[0], values[0]
+	for _, v := range values {
+		low, high = min(low, v), max(high, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if high > low {
+			level = (v - low) * (len(sparkLevels) - 1) / (high - low)
+		}
+		b.WriteRune(sparkLevels[level])
+	}
+	return b.String()
+}
+
+/*
+renderBarChart draws labelled horizontal bars scaled to the available width.
+
+Parameters:
+- labels []string: Row labels
+- values []int: Row values (same length as labels)
+- width int: Total columns available for a row
+- color string: ANSI color for the bars
+
+Returns:
+- []string: One rendered line per row
+*/
+func renderBarChart(labels []string, values []int, width int, color string) []string {
+	labelWidth, peak := 0, 0
+	for i, label := range labels {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(label))
+		peak = max(peak, values[i])
+	}
+	countWidth := len(strconv.Itoa(peak))
+
+	// Row layout: indent, label, space, bar, space, count
+	barWidth := width - len(chartIndent) - labelWidth - countWidth - 2
+	if barWidth < 1 {
+		barWidth = 1
+	}
+
+	lines := make([]string, len(labels))
+	for i, label := range labels {
+		length := 0
+		if peak > 0 {
+			length = values[i] * barWidth / peak
+		}
+		if values[i] > 0 && length == 0 {
+			length = 1 // Keep non-zero values visible
+		}
+
+		padding := strings.Repeat(" ", labelWidth-utf8.RuneCountInString(label))
+		lines[i] = fmt.Sprintf("%s%s%s %s%s%s %*d",
+			chartIndent, label, padding,
+			color, strings.Repeat(barGlyph, length), ColorReset,
+			countWidth+barWidth-length, values[i])
+	}
+	return lines
+}
+
+/*
+attemptBuckets groups attempt counts into at most HistogramBuckets ranges.
+
+Returns:
+- []string: Bucket labels such as "1-3"
+- func(int) int: Maps an attempt count to its bucket index
+*/
+func attemptBuckets(maxAttempts int) ([]string, func(int) int) {
+	size := (maxAttempts + HistogramBuckets - 1) / HistogramBuckets
+	if size < 1 {
+		size = 1
+	}
+	count := (maxAttempts + size - 1) / size
+
+	labels := make([]string, count)
+	for i := range labels {
+		low, high := i*size+1, (i+1)*size
+		if low == high {
+			labels[i] = strconv.Itoa(low)
+		} else {
+			labels[i] = fmt.Sprintf("%d-%d", low, high)
+		}
+	}
+
+	return labels, func(attempts int) int {
+		return min(max(attempts-1, 0)/size, count-1)
+	}
+}
+
+/*
+displayPerformanceCharts renders the performance trend section of the
+statistics dashboard.
+
+Charts Rendered:
+1. Sparkline of attempts per game over time
+2. Bar chart of wins per player
+3. Histogram of attempts per game for each difficulty
+
+Terminals narrower than MinChartWidth receive the plain list of the last five
+games instead, which remains readable at any width.
+
+Parameters:
+- gameHistory []GameSession: Complete session history
+*/
+func displayPerformanceCharts(gameHistory []GameSession) {
+	width := terminalWidth()
+	if width < MinChartWidth {
+		displayRecentGamesList(gameHistory)
+		return
+	}
+
+	// Sparkline of attempts over time
+	attempts := make([]int, len(gameHistory))
+	high := 0 // Across the whole history, for the histogram buckets
+	for i, session := range gameHistory {
+		attempts[i] = session.Attempts
+		high = max(high, session.Attempts)
+	}
+	sparkWidth := width - len(chartIndent)
+	shown := min(len(attempts), sparkWidth)
+	recent := attempts[len(attempts)-shown:]
+	recentLow, recentHigh := recent[0], recent[0]
+	for _, value := range recent {
+		recentLow, recentHigh = min(recentLow, value), max(recentHigh, value)
+	}
+	fmt.Printf("\n%s Attempts Trend (Last %d Games):%s\n", ColorCyan, shown, ColorReset)
+	fmt.Printf("%s%s%s%s\n", chartIndent, ColorYellow, renderSparkline(attempts, sparkWidth), ColorReset)
+	fmt.Printf("%smin %d • max %d • latest %d\n", chartIndent, recentLow, recentHigh, attempts[len(attempts)-1])
+
+	// Bar chart of wins per player, in order of first appearance
+	wins := make(map[string]int)
+	var players []string
+	for _, session := range gameHistory {
+		for _, player := range sessionPlayers(session) {
+			if _, seen := wins[player]; !seen {
+				wins[player] = 0
+				players = append(players, player)
+			}
+		}
+		if session.Winner != "" {
+			wins[session.Winner]++
+		}
+	}
+	values := make([]int, len(players))
+	for i, player := range players {
+		values[i] = wins[player]
+	}
+	fmt.Printf("\n%s Wins per Player:%s\n", ColorCyan, ColorReset)
+	for _, line := range renderBarChart(players, values, width, ColorGreen) {
+		fmt.Println(line)
+	}
+
+	// Histogram of attempts for each difficulty played
+	byDifficulty := make(map[string][]int)
+	for _, session := range gameHistory {
+		byDifficulty[session.Difficulty] = append(byDifficulty[session.Difficulty], session.Attempts)
+	}
+	labels, bucketOf := attemptBuckets(high)
+	for _, difficulty := range historyDifficulties() {
+		samples := byDifficulty[difficulty]
+		if len(samples) == 0 {
+			continue
+		}
+
+		counts := make([]int, len(labels))
+		for _, attempts := range samples {
+			counts[bucketOf(attempts)]++
+		}
+		fmt.Printf("\n%s Attempts Histogram - %s:%s\n", ColorCyan, strings.Title(difficulty), ColorReset)
+		for _, line := range renderBarChart(labels, counts, width, ColorBlue) {
+			fmt.Println(line)
+		}
+	}
+}
+
+/*
+displayRecentGamesList prints the last five games as plain text.
+
+This is the narrow-terminal fallback for displayPerformanceCharts.
+*/
+func displayRecentGamesList(gameHistory []GameSession) {
+	totalGames := len(gameHistory)
+	fmt.Printf("\n%s Recent Performance (Last %d Games):%s\n", ColorCyan,
+		min(5, totalGames), ColorReset)
+
+	startIdx := max(0, totalGames-5)
+	for i := startIdx; i < totalGames; i++ {
+		session := gameHistory[i]
+		fmt.Printf("  Game %d: %s%s%s won in %s%d attempts%s (%s%s%s)\n",
+			i+1, ColorGreen, session.Winner, ColorReset,
+			ColorYellow, session.Attempts, ColorReset,
+			ColorBlue, strings.Title(session.Difficulty), ColorReset)
+	}
+}
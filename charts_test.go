@@ -0,0 +1,57 @@
+package main
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestRenderSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		width  int
+		want   string
+	}{
+		{"no values", nil, 10, ""},
+		{"no room", []int{1, 2}, 0, ""},
+		{"flat series", []int{4, 4, 4}, 10, "▁▁▁"},
+		{"full scale", []int{1, 8, 2, 7, 3, 6, 4, 5}, 10, "▁█▂▇▃▆▄▅"},
+		{"scaled between min and max", []int{10, 20, 30}, 10, "▁▄█"},
+		{"keeps the most recent values", []int{100, 1, 2, 3}, 3, "▁▄█"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderSparkline(tt.values, tt.width); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttemptBuckets(t *testing.T) {
+	tests := []struct {
+		high    int
+		labels  []string
+		buckets map[int]int // Attempts -> bucket index
+	}{
+		{1, []string{"1"}, map[int]int{1: 0, 5: 0}},
+		{6, []string{"1", "2", "3", "4", "5", "6"}, map[int]int{1: 0, 6: 5}},
+		{7, []string{"1-2", "3-4", "5-6", "7-8"}, map[int]int{1: 0, 2: 0, 3: 1, 7: 3}},
+		{12, []string{"1-2", "3-4", "5-6", "7-8", "9-10", "11-12"}, map[int]int{0: 0, 11: 5, 40: 5}},
+		{20, []string{"1-4", "5-8", "9-12", "13-16", "17-20"}, map[int]int{4: 0, 5: 1, 20: 4}},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.high), func(t *testing.T) {
+			labels, bucketOf := attemptBuckets(tt.high)
+			if !slices.Equal(labels, tt.labels) {
+				t.Errorf("labels = %v, want %v", labels, tt.labels)
+			}
+			for attempts, want := range tt.buckets {
+				if got := bucketOf(attempts); got != want {
+					t.Errorf("bucket of %d = %d, want %d", attempts, got, want)
+				}
+			}
+		})
+	}
+}
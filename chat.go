@@ -0,0 +1,215 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Chat constants - Generous enough for banter, tight enough that one player
+// cannot drown out the game
+const (
+	MaxChatLength = 200             // Longest chat message in runes
+	ChatBurst     = 5               // Messages a player may send in a row
+	ChatRefill    = 2 * time.Second // Time to earn back one message
+)
+
+// Emotes maps the quick emote names players may send to how they are shown
+var Emotes = map[string]string{
+	"gg":    "🤝 Good game!",
+	"lol":   "😂",
+	"wow":   "😮",
+	"think": "🤔",
+	"fire":  "🔥",
+	"sad":   "😢",
+	"clap":  "👏",
+}
+
+// Chat errors returned to network clients
+var (
+	ErrRateLimited = errors.New("slow down")
+	ErrInvalidChat = errors.New("invalid chat message")
+)
+
+/*
+rateLimiter is a token bucket: it allows bursts of up to burst actions and
+then one action per refill interval.
+
+The zero value is not usable; create limiters with newRateLimiter.
+*/
+type rateLimiter struct {
+	mu     sync.Mutex
+	burst  float64
+	refill time.Duration
+	tokens float64
+	last   time.Time
+}
+
+/*
+newRateLimiter creates a full token bucket.
+
+Parameters:
+- burst int: Actions allowed in a row
+- refill time.Duration: Time to earn back one action
+
+Returns:
+- *rateLimiter: Limiter allowing a full burst right away
+*/
+func newRateLimiter(burst int, refill time.Duration) *rateLimiter {
+	return &rateLimiter{
+		burst:  float64(burst),
+		refill: refill,
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+/*
+Allow reports whether an action may happen now, consuming a token if so.
+*/
+func (l *rateLimiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += float64(now.Sub(l.last)) / float64(l.refill)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+/*
+Chat relays a seated player's chat message or emote to the room.
+
+Exactly one of text and emote must be set. Messages are trimmed, stripped
+of control characters and capped at MaxChatLength runes.
+
+Parameters:
+- player string: Seated player speaking
+- text string: Free-form message
+- emote string: One of the Emotes names
+
+Returns:
+- error: ErrInvalidChat, ErrRateLimited or ErrMatchOver
+*/
+func (r *Room) Chat(player, text, emote string) error {
+	text = sanitizeChat(text)
+	emote = strings.ToLower(strings.TrimSpace(emote))
+
+	switch {
+	case text == "" && emote == "":
+		return fmt.Errorf("%w: message is empty", ErrInvalidChat)
+	case text != "" && emote != "":
+		return fmt.Errorf("%w: send either text or an emote", ErrInvalidChat)
+	case emote != "" && Emotes[emote] == "":
+		return fmt.Errorf("%w: unknown emote %q (try %s)", ErrInvalidChat, emote, strings.Join(emoteNames(), ", "))
+	}
+
+	r.mu.Lock()
+	limiter := r.chatLimits[player]
+	if limiter == nil {
+		limiter = newRateLimiter(ChatBurst, ChatRefill)
+		r.chatLimits[player] = limiter
+	}
+	r.mu.Unlock()
+
+	if !limiter.Allow() {
+		return fmt.Errorf("%w: too many chat messages", ErrRateLimited)
+	}
+	return r.Match.Chat(player, text, emote)
+}
+
+/*
+sanitizeChat cleans up a chat message for display on other players'
+terminals, where control characters could rewrite the screen.
+*/
+func sanitizeChat(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, text)
+	text = strings.TrimSpace(text)
+
+	if runes := []rune(text); len(runes) > MaxChatLength {
+		text = string(runes[:MaxChatLength])
+	}
+	return text
+}
+
+/*
+chatLine renders a chat message or emote for terminal display.
+*/
+func chatLine(text, emote string) string {
+	if emote != "" {
+		if shown := Emotes[emote]; shown != "" {
+			return shown
+		}
+		return ":" + emote + ":"
+	}
+	return text
+}
+
+/*
+emoteNames lists the available emotes alphabetically, for help texts.
+*/
+func emoteNames() []string {
+	names := make([]string, 0, len(Emotes))
+	for name := range Emotes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+/*
+muteList holds the players one connection does not want to hear from.
+
+Muting is per listener: the muted player is not told and everyone else
+still sees their messages.
+*/
+type muteList struct {
+	mu    sync.Mutex
+	names map[string]bool
+}
+
+/*
+Set mutes or unmutes a player.
+*/
+func (m *muteList) Set(player string, muted bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.names == nil {
+		m.names = make(map[string]bool)
+	}
+	if muted {
+		m.names[player] = true
+	} else {
+		delete(m.names, player)
+	}
+}
+
+/*
+Hides reports whether an event should be withheld from this listener.
+*/
+func (m *muteList) Hides(event MatchEvent) bool {
+	if event.Type != EventChat {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.names[event.Player]
+}
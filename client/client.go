@@ -0,0 +1,279 @@
+/*
+Package client connects to a number guessing game server.
+
+It handles the join handshake and message framing so bots and dashboards can
+work with protocol.Message values directly:
+
+	c, err := client.Dial("localhost:7777", "RoboGuesser")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer c.Close()
+
+	for {
+		msg, err := c.Next()
+		if err != nil {
+			break
+		}
+		if msg.Type == protocol.TypeTurnStart && msg.YourTurn {
+			c.Guess(50)
+		}
+	}
+*/
+package client
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"gaming/my-guessing-game/protocol"
+)
+
+// DialTimeout bounds connection establishment and the join handshake
+const DialTimeout = 10 * time.Second
+
+/*
+Client is a connected, joined player.
+*/
+type Client struct {
+	conn   net.Conn
+	reader *protocol.Reader
+	writer *protocol.Writer
+	turn   atomic.Int64 // Last turn announced as ours; guesses name it
+
+	// Welcome is the server's answer to the join handshake, describing the
+	// game configuration and the players seated so far. Welcome.Token
+	// resumes the seat if the connection drops.
+	Welcome protocol.Message
+}
+
+/*
+Dial connects to a server and joins any open room.
+
+Parameters:
+- addr string: Server address (host:port)
+- name string: Player name to join as
+
+Returns:
+- *Client: Joined client
+- error: Connection failure, or *protocol.RemoteError if the join was refused
+*/
+func Dial(addr, name string) (*Client, error) {
+	return handshake(addr, protocol.Join(name, ""))
+}
+
+/*
+JoinRoom connects to a server and joins the room with the given invite code.
+*/
+func JoinRoom(addr, name, room string) (*Client, error) {
+	return handshake(addr, protocol.Join(name, room))
+}
+
+/*
+CreateRoom connects to a server and opens a new room hosted by name.
+
+The invite code to share is in Welcome.Room; as host, the client may start
+the game early with Start.
+
+Parameters:
+- addr string: Server address (host:port)
+- name string: Host's player name
+- difficulty string: easy, medium or hard ("" for the server default)
+- capacity int: Number of seats (0 for the server default)
+- timeLimit time.Duration: Time per guess (0 for the server default)
+*/
+func CreateRoom(addr, name, difficulty string, capacity int, timeLimit time.Duration) (*Client, error) {
+	return handshake(addr, protocol.Create(name, difficulty, capacity, timeLimit.Milliseconds()))
+}
+
+/*
+Resume reconnects to a seat after a dropped connection, using the session
+token from the original Welcome. The server replays the guess history in a
+"history" message followed by the turn in progress.
+*/
+func Resume(addr, room, token string) (*Client, error) {
+	return handshake(addr, protocol.Resume(room, token))
+}
+
+/*
+Watch connects to a room as a read-only spectator.
+
+Spectators receive every guess as a "guess" announcement carrying the
+feasible range (Low-High), and the target too if the host allows it.
+Welcome.Spectator is set; guesses are refused with read_only.
+*/
+func Watch(addr, room string) (*Client, error) {
+	return handshake(addr, protocol.Watch(room))
+}
+
+/*
+ListRooms fetches the server's lobby without joining.
+
+Returns:
+- []protocol.RoomInfo: Rooms that have not started yet and have free seats
+- error: Connection or protocol failure
+*/
+func ListRooms(addr string) ([]protocol.RoomInfo, error) {
+	conn, err := net.DialTimeout("tcp", addr, DialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(DialTimeout))
+	if err := protocol.NewWriter(conn).Write(protocol.Message{Type: protocol.TypeListRooms, Version: protocol.Version}); err != nil {
+		return nil, err
+	}
+	reply, err := protocol.NewReader(conn).Read()
+	if err != nil {
+		return nil, err
+	}
+	if err := reply.Err(); err != nil {
+		return nil, err
+	}
+	if reply.Type != protocol.TypeRooms {
+		return nil, fmt.Errorf("client: expected rooms, got %q", reply.Type)
+	}
+	return reply.Rooms, nil
+}
+
+/*
+handshake connects and sends a join or create message, waiting for the
+welcome.
+*/
+func handshake(addr string, hello protocol.Message) (*Client, error) {
+	conn, err := net.DialTimeout("tcp", addr, DialTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		conn:   conn,
+		reader: protocol.NewReader(conn),
+		writer: protocol.NewWriter(conn),
+	}
+
+	conn.SetDeadline(time.Now().Add(DialTimeout))
+	if err := c.writer.Write(hello); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	welcome, err := c.reader.Read()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := welcome.Err(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if welcome.Type != protocol.TypeWelcome {
+		conn.Close()
+		return nil, fmt.Errorf("client: expected welcome, got %q", welcome.Type)
+	}
+	if welcome.Version != 0 && welcome.Version != protocol.Version {
+		conn.Close()
+		return nil, fmt.Errorf("client: server speaks protocol v%d, client speaks v%d", welcome.Version, protocol.Version)
+	}
+	conn.SetDeadline(time.Time{})
+
+	c.Welcome = welcome
+	return c, nil
+}
+
+/*
+Next blocks until the next server message arrives.
+
+Error messages from the server are returned as ordinary messages so callers
+can decide whether they are fatal; use Message.Err to inspect them.
+
+Returns:
+- protocol.Message: Next message
+- error: Read failure or io.EOF once the server closes the connection
+*/
+func (c *Client) Next() (protocol.Message, error) {
+	msg, err := c.reader.Read()
+	if err == nil && msg.Type == protocol.TypeTurnStart && msg.YourTurn {
+		c.turn.Store(int64(msg.Turn))
+	}
+	return msg, err
+}
+
+/*
+Guess submits a numeric guess.
+*/
+func (c *Client) Guess(value int) error {
+	return c.GuessText(strconv.Itoa(value))
+}
+
+/*
+GuessText submits a guess exactly as typed; the server validates it.
+
+The guess names the last turn Next reported as ours, so the server counts
+at most one guess per turn however many are sent.
+*/
+func (c *Client) GuessText(text string) error {
+	return c.writer.Write(protocol.Guess(text, int(c.turn.Load())))
+}
+
+/*
+Start begins the game early; only the room's host may do this.
+*/
+func (c *Client) Start() error {
+	return c.writer.Write(protocol.Message{Type: protocol.TypeStart})
+}
+
+/*
+SetSpectatorsSeeTarget asks the server to show or hide the target from
+spectators. Only the host may change it; the server confirms with a
+"settings" message.
+*/
+func (c *Client) SetSpectatorsSeeTarget(enabled bool) error {
+	return c.writer.Write(protocol.Message{Type: protocol.TypeSettings, SpectatorsSeeTarget: enabled})
+}
+
+/*
+Chat sends a chat message to everyone in the room. Chat never counts as a
+guess, even on the sender's turn.
+*/
+func (c *Client) Chat(text string) error {
+	return c.writer.Write(protocol.Chat(text, ""))
+}
+
+/*
+Emote sends a quick emote such as "gg" or "wow".
+*/
+func (c *Client) Emote(name string) error {
+	return c.writer.Write(protocol.Chat("", name))
+}
+
+/*
+Mute stops (or with muted false, resumes) delivery of a player's chat to
+this client.
+*/
+func (c *Client) Mute(player string, muted bool) error {
+	msgType := protocol.TypeUnmute
+	if muted {
+		msgType = protocol.TypeMute
+	}
+	return c.writer.Write(protocol.Message{Type: msgType, Player: player})
+}
+
+/*
+Send writes an arbitrary message, for protocol extensions not covered by the
+helper methods.
+*/
+func (c *Client) Send(msg protocol.Message) error {
+	return c.writer.Write(msg)
+}
+
+/*
+Close disconnects from the server.
+*/
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"gaming/my-guessing-game/protocol"
+)
+
+/*
+fakeServer accepts a single connection on a local port and hands it to
+serve, standing in for "guessing-game serve".
+
+Returns:
+- string: Address to dial
+*/
+func fakeServer(t *testing.T, serve func(r *protocol.Reader, w *protocol.Writer)) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		serve(protocol.NewReader(conn), protocol.NewWriter(conn))
+	}()
+	return listener.Addr().String()
+}
+
+func TestHandshake(t *testing.T) {
+	welcome := protocol.Message{Type: protocol.TypeWelcome, Version: protocol.Version, Room: "ABCD", Token: "secret"}
+	tests := []struct {
+		name    string
+		reply   protocol.Message
+		wantErr bool
+	}{
+		{"welcomed", welcome, false},
+		{"welcome without a version", protocol.Message{Type: protocol.TypeWelcome, Room: "ABCD"}, false},
+		{"refused", protocol.Error(protocol.CodeNameTaken, "name already taken"), true},
+		{"other version", protocol.Message{Type: protocol.TypeWelcome, Version: protocol.Version + 1}, true},
+		{"not a welcome", protocol.Message{Type: protocol.TypeRooms}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hello := make(chan protocol.Message, 1)
+			addr := fakeServer(t, func(r *protocol.Reader, w *protocol.Writer) {
+				msg, _ := r.Read()
+				hello <- msg
+				w.Write(tt.reply)
+			})
+
+			c, err := JoinRoom(addr, "Alice", "ABCD")
+			if sent := <-hello; sent.Type != protocol.TypeJoin || sent.Name != "Alice" || sent.Room != "ABCD" || sent.Version != protocol.Version {
+				t.Errorf("sent %+v, want a v%d join for Alice in ABCD", sent, protocol.Version)
+			}
+			if tt.wantErr {
+				if err == nil {
+					c.Close()
+					t.Fatal("handshake succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("handshake: %v", err)
+			}
+			defer c.Close()
+			if c.Welcome.Room != tt.reply.Room || c.Welcome.Token != tt.reply.Token {
+				t.Errorf("got welcome %+v, want %+v", c.Welcome, tt.reply)
+			}
+		})
+	}
+}
+
+func TestHandshakeRemoteError(t *testing.T) {
+	addr := fakeServer(t, func(r *protocol.Reader, w *protocol.Writer) {
+		r.Read()
+		w.Write(protocol.Error(protocol.CodeGameFull, "room is full"))
+	})
+
+	_, err := Dial(addr, "Alice")
+	var remote *protocol.RemoteError
+	if !errors.As(err, &remote) || remote.Code != protocol.CodeGameFull {
+		t.Errorf("got %v, want a RemoteError with code %s", err, protocol.CodeGameFull)
+	}
+}
+
+func TestGuessNamesTurn(t *testing.T) {
+	guesses := make(chan protocol.Message, 3)
+	addr := fakeServer(t, func(r *protocol.Reader, w *protocol.Writer) {
+		r.Read()
+		w.Write(protocol.Message{Type: protocol.TypeWelcome, Version: protocol.Version})
+		w.Write(protocol.Message{Type: protocol.TypeTurnStart, Turn: 2, Player: "Alice", YourTurn: true})
+		w.Write(protocol.Message{Type: protocol.TypeTurnStart, Turn: 3, Player: "Bob"})
+		for range 2 {
+			msg, err := r.Read()
+			if err != nil {
+				return
+			}
+			guesses <- msg
+		}
+	})
+
+	c, err := Dial(addr, "Alice")
+	if err != nil {
+		t.Fatalf("handshake: %v", err)
+	}
+	defer c.Close()
+
+	if err := c.Guess(7); err != nil {
+		t.Fatal(err)
+	}
+	if msg := <-guesses; msg.Turn != 0 {
+		t.Errorf("guess before any turn_start named turn %d, want 0", msg.Turn)
+	}
+
+	for range 2 {
+		if _, err := c.Next(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.GuessText("42"); err != nil {
+		t.Fatal(err)
+	}
+	if msg := <-guesses; msg.Type != protocol.TypeGuess || msg.Guess != "42" || msg.Turn != 2 {
+		t.Errorf("sent %+v, want guess 42 for turn 2, the last one that was ours", msg)
+	}
+}
+
+func TestListRooms(t *testing.T) {
+	rooms := []protocol.RoomInfo{{Code: "ABCD", Host: "Alice", Difficulty: "easy", MaxRange: 50, Players: 1, Capacity: 2}}
+	addr := fakeServer(t, func(r *protocol.Reader, w *protocol.Writer) {
+		if msg, _ := r.Read(); msg.Type == protocol.TypeListRooms {
+			w.Write(protocol.Message{Type: protocol.TypeRooms, Rooms: rooms})
+		}
+	})
+
+	got, err := ListRooms(addr)
+	if err != nil {
+		t.Fatalf("listing rooms: %v", err)
+	}
+	if len(got) != 1 || got[0] != rooms[0] {
+		t.Errorf("got %+v, want %+v", got, rooms)
+	}
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"gaming/my-guessing-game/protocol"
+)
+
+/*
+Server is a game server found on the local network.
+*/
+type Server struct {
+	Addr  string              // TCP address to pass to Dial, JoinRoom and friends
+	Name  string              // Host name the server announced
+	Rooms []protocol.RoomInfo // Rooms with free seats at the last announcement
+	Open  int                 // Rooms with free seats, including any not in Rooms
+}
+
+/*
+Discover listens for LAN announcements and returns the servers heard.
+
+Servers announce themselves every couple of seconds, so a wait of a few
+seconds finds every server on the network segment. Only one process per
+machine can listen on the discovery port at a time.
+
+Parameters:
+- listenAddr string: UDP address to listen on, e.g. ":7778"
+- wait time.Duration: How long to listen
+
+Returns:
+- []Server: Servers heard, sorted by address; the latest announcement wins
+- error: Failure to listen on the discovery port
+*/
+func Discover(listenAddr string, wait time.Duration) ([]Server, error) {
+	conn, err := net.ListenPacket("udp", listenAddr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	servers := make(map[string]Server)
+	buf := make([]byte, 64*1024)
+	conn.SetReadDeadline(time.Now().Add(wait))
+	for {
+		n, from, err := conn.ReadFrom(buf)
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var announcement protocol.Announcement
+		if json.Unmarshal(buf[:n], &announcement) != nil || announcement.Type != protocol.TypeAnnounce {
+			continue // Not ours
+		}
+		udpAddr, ok := from.(*net.UDPAddr)
+		if !ok || announcement.Port <= 0 {
+			continue
+		}
+		addr := net.JoinHostPort(udpAddr.IP.String(), strconv.Itoa(announcement.Port))
+		servers[addr] = Server{Addr: addr, Name: announcement.Server, Rooms: announcement.Rooms,
+			Open: max(announcement.OpenRooms, len(announcement.Rooms))}
+	}
+
+	found := make([]Server, 0, len(servers))
+	for _, server := range servers {
+		found = append(found, server)
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Addr < found[j].Addr })
+	return found, nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+)
+
+/*
+runCommand dispatches command-line subcommands.
+
+Running the binary without arguments starts the interactive game; any
+arguments select one of the non-interactive subcommands below.
+
+Parameters:
+- args []string: Command-line arguments without the program name
+
+Returns:
+- int: Process exit code (0 success, 1 runtime failure, 2 usage error)
+*/
+func runCommand(args []string) int {
+	switch args[0] {
+	case "leaderboard":
+		return runLeaderboardCommand(args[1:])
+	case "export":
+		return runExportCommand(args[1:])
+	case "serve":
+		return runServeCommand(args[1:])
+	case "join":
+		return runJoinCommand(args[1:])
+	case "http":
+		return runHTTPCommand(args[1:])
+	case "discover":
+		return runDiscoverCommand(args[1:])
+	case "admin":
+		return runAdminCommand(args[1:])
+	case "simulate":
+		return runSimulateCommand(args[1:])
+	case "balance":
+		return runBalanceCommand(args[1:])
+	case "help", "-h", "-help", "--help":
+		printUsage()
+		return 0
+	default:
+		printColoredMessage(fmt.Sprintf("Unknown command %q.", args[0]), ColorRed)
+		printUsage()
+		return 2
+	}
+}
+
+/*
+printUsage lists the available subcommands.
+*/
+func printUsage() {
+	fmt.Printf("%sUsage:%s\n", ColorCyan, ColorReset)
+	fmt.Printf("  %sguessing-game%s                 Play the interactive game\n", ColorGreen, ColorReset)
+	fmt.Printf("  %sguessing-game leaderboard%s     Show a filtered leaderboard\n", ColorGreen, ColorReset)
+	fmt.Printf("  %sguessing-game export%s          Export history and stats to CSV, JSON or Markdown\n", ColorGreen, ColorReset)
+	fmt.Printf("  %sguessing-game serve%s           Host a network game over TCP\n", ColorGreen, ColorReset)
+	fmt.Printf("  %sguessing-game join%s            Join or spectate a network game\n", ColorGreen, ColorReset)
+	fmt.Printf("  %sguessing-game discover%s        Find games on the local network and join one\n", ColorGreen, ColorReset)
+	fmt.Printf("  %sguessing-game http%s            Serve games, leaderboard and history as a JSON API\n", ColorGreen, ColorReset)
+	fmt.Printf("  %sguessing-game admin%s           Manage the rooms of a running server\n", ColorGreen, ColorReset)
+	fmt.Printf("  %sguessing-game simulate%s        Run a headless bot tournament and compare strategies\n", ColorGreen, ColorReset)
+	fmt.Printf("  %sguessing-game balance%s         Check the scoring multipliers against simulated play\n", ColorGreen, ColorReset)
+	fmt.Printf("  %sguessing-game help%s            Show this message\n", ColorGreen, ColorReset)
+	fmt.Printf("\nRun a subcommand with %s-h%s for its options.\n", ColorYellow, ColorReset)
+}
+
+/*
+loadSaveDataOrReport loads the save file for a subcommand, printing a
+user-friendly message on failure.
+
+Returns:
+- *SaveData: Loaded save data, or nil when loading failed
+*/
+func loadSaveDataOrReport() *SaveData {
+	path := dataFilePath()
+	data, err := loadSaveData(path)
+	if err != nil {
+		printColoredMessage(fmt.Sprintf("Could not load game data: %v", err), ColorRed)
+		return nil
+	}
+	return data
+}
+
+/*
+runLeaderboardCommand prints a leaderboard filtered by difficulty and time
+window and ranked by the requested mode.
+
+Usage:
+
+	guessing-game leaderboard [-difficulty hard] [-window week] [-mode average] [-limit 10]
+
+Parameters:
+- args []string: Subcommand arguments
+
+Returns:
+- int: Process exit code
+*/
+func runLeaderboardCommand(args []string) int {
+	flags := flag.NewFlagSet("leaderboard", flag.ContinueOnError)
+	difficulty := flags.String("difficulty", AllDifficulties, "difficulty filter: easy, medium, hard, adaptive or all")
+	window := flags.String("window", WindowAllTime, "time window: all, today, week, month or season")
+	mode := flags.String("mode", RankByTotal, "ranking mode: total, average or best")
+	limit := flags.Int("limit", 10, "maximum number of players to list (0 for all)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	query, err := LeaderboardQuery{Difficulty: *difficulty, Window: *window, Mode: *mode}.normalize()
+	if err != nil {
+		printColoredMessage(err.Error(), ColorRed)
+		return 2
+	}
+
+	data := loadSaveDataOrReport()
+	if data == nil {
+		return 1
+	}
+
+	entries, err := queryLeaderboard(data.GameHistory, query, time.Now())
+	if err != nil {
+		printColoredMessage(err.Error(), ColorRed)
+		return 2
+	}
+
+	printColoredHeader("Leaderboard")
+	fmt.Printf("%s%s%s\n", ColorPurple, describeLeaderboardQuery(query), ColorReset)
+	displayLeaderboard(entries, query.Mode, *limit)
+	printSeparator()
+	return 0
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gaming/my-guessing-game/client"
+	"gaming/my-guessing-game/protocol"
+)
+
+// LAN discovery constants
+const (
+	AnnounceInterval = 2 * time.Second // How often a server announces its rooms
+	DiscoveryWait    = 3 * time.Second // Default listening time for "discover"
+)
+
+// DefaultAnnounceAddr broadcasts to every machine on the local network segment
+var DefaultAnnounceAddr = net.JoinHostPort("255.255.255.255", strconv.Itoa(protocol.DiscoveryPort))
+
+/*
+announceRooms broadcasts the lobby's open rooms over UDP until the context
+is cancelled, so "discover" can find this server.
+
+Parameters:
+- ctx context.Context: Stops announcing when done
+- lobby *Lobby: Rooms to announce
+- port int: TCP port players should connect to
+- target string: UDP address announcements are sent to
+*/
+func announceRooms(ctx context.Context, lobby *Lobby, port int, target string) {
+	addr, err := net.ResolveUDPAddr("udp", target)
+	if err != nil {
+		printColoredMessage(fmt.Sprintf("LAN announcements disabled: %v", err), ColorYellow)
+		return
+	}
+	conn, err := net.DialUDP("udp", nil, addr)
+	if err != nil {
+		printColoredMessage(fmt.Sprintf("LAN announcements disabled: %v", err), ColorYellow)
+		return
+	}
+	defer conn.Close()
+
+	hostname, _ := os.Hostname()
+	ticker := time.NewTicker(AnnounceInterval)
+	defer ticker.Stop()
+
+	reported := false
+	for {
+		datagram := announcement(hostname, port, lobby.OpenRooms())
+		if _, err := conn.Write(datagram); err != nil {
+			if !reported {
+				// Report once per outage; the network may come back, so keep trying quietly
+				printColoredMessage(fmt.Sprintf("LAN announcement failed: %v", err), ColorYellow)
+				reported = true
+			}
+		} else {
+			reported = false
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+/*
+announcement encodes a LAN announcement listing as many of the open rooms
+as fit in one unfragmented datagram; clients list the rest over TCP.
+
+Parameters:
+- hostname string: Name of the server machine
+- port int: TCP port players should connect to
+- rooms []protocol.RoomInfo: Rooms with free seats
+
+Returns:
+  - []byte: Datagram of at most protocol.MaxAnnouncement bytes, unless the
+    server name alone is longer
+*/
+func announcement(hostname string, port int, rooms []protocol.RoomInfo) []byte {
+	listed := min(len(rooms), protocol.MaxAnnouncedRooms)
+	for {
+		datagram, _ := json.Marshal(protocol.Announcement{
+			Type:      protocol.TypeAnnounce,
+			Version:   protocol.Version,
+			Server:    hostname,
+			Port:      port,
+			Rooms:     append([]protocol.RoomInfo{}, rooms[:listed]...),
+			OpenRooms: len(rooms),
+		})
+		if len(datagram) <= protocol.MaxAnnouncement || listed == 0 {
+			return datagram
+		}
+		listed--
+	}
+}
+
+/*
+runDiscoverCommand finds games on the local network and joins one.
+
+Usage:
+
+	guessing-game discover [-wait 3s] [-listen :7778] [-name Alice]
+
+Servers started with "serve" announce their open rooms by UDP broadcast.
+The rooms heard are listed with their host, difficulty and free seats; the
+player picks one by number and plays it exactly as with "join".
+
+Parameters:
+- args []string: Subcommand arguments
+
+Returns:
+- int: Process exit code
+*/
+func runDiscoverCommand(args []string) int {
+	flags := flag.NewFlagSet("discover", flag.ContinueOnError)
+	wait := flags.Duration("wait", DiscoveryWait, "how long to listen for announcements")
+	listen := flags.String("listen", fmt.Sprintf(":%d", protocol.DiscoveryPort), "UDP address to listen on")
+	name := flags.String("name", "", "player name (prompted for if omitted)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	printColoredMessage(fmt.Sprintf("Looking for games on the local network (%s)...", *wait), ColorCyan)
+	servers, err := client.Discover(*listen, *wait)
+	if err != nil {
+		printColoredMessage(fmt.Sprintf("Could not listen for announcements: %v", err), ColorRed)
+		return 1
+	}
+
+	type choice struct {
+		addr string
+		room protocol.RoomInfo
+	}
+	var choices []choice
+
+	printColoredHeader("📡 Games on Your Network")
+	if len(servers) == 0 {
+		printColoredMessage("No servers found. Is one running with 'guessing-game serve'?", ColorYellow)
+		printSeparator()
+		return 1
+	}
+	for _, server := range servers {
+		fmt.Printf("%s%s%s (%s)\n", ColorBlue, server.Name, ColorReset, server.Addr)
+		if len(server.Rooms) == 0 {
+			fmt.Printf("     no open rooms - create one with: guessing-game join -addr %s -create\n", server.Addr)
+		}
+		for _, room := range server.Rooms {
+			choices = append(choices, choice{server.Addr, room})
+			fmt.Printf("  %s%2d)%s %s  %-7s (1-%d)  %d free of %d seats  host: %s\n",
+				ColorGreen, len(choices), ColorReset, room.Code, strings.Title(room.Difficulty), room.MaxRange,
+				room.Capacity-room.Players, room.Capacity, room.Host)
+		}
+		if more := server.Open - len(server.Rooms); more > 0 {
+			fmt.Printf("     and %d more - list them all with: guessing-game join -addr %s\n", more, server.Addr)
+		}
+	}
+	printSeparator()
+	if len(choices) == 0 {
+		return 0
+	}
+
+	fmt.Print("Enter a number to join, or press Enter to quit: ")
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return 0
+	}
+	index, err := strconv.Atoi(line)
+	if err != nil || index < 1 || index > len(choices) {
+		printColoredMessage("No such game.", ColorRed)
+		return 2
+	}
+
+	picked := choices[index-1]
+	joinArgs := []string{"-addr", picked.addr, "-room", picked.room.Code}
+	if *name != "" {
+		joinArgs = append(joinArgs, "-name", *name)
+	}
+	return runJoinCommand(joinArgs)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"gaming/my-guessing-game/protocol"
+)
+
+func TestAnnouncement(t *testing.T) {
+	openRooms := func(count int, host string) []protocol.RoomInfo {
+		rooms := make([]protocol.RoomInfo, count)
+		for i := range rooms {
+			rooms[i] = protocol.RoomInfo{Code: fmt.Sprintf("R%03d", i), Host: host, Difficulty: "medium", MaxRange: 100, Capacity: 2}
+		}
+		return rooms
+	}
+
+	tests := []struct {
+		name   string
+		rooms  []protocol.RoomInfo
+		listed int
+	}{
+		{"no rooms", nil, 0},
+		{"every room fits", openRooms(3, "Alice"), 3},
+		{"capped room count", openRooms(500, "Alice"), protocol.MaxAnnouncedRooms},
+		{"long host names", openRooms(5, strings.Repeat("a", 600)), 1},
+		{"a room too long to list", openRooms(2, strings.Repeat("a", 2000)), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			datagram := announcement("server", 7777, tt.rooms)
+			if len(datagram) > protocol.MaxAnnouncement {
+				t.Errorf("datagram is %d bytes, want at most %d", len(datagram), protocol.MaxAnnouncement)
+			}
+			var got protocol.Announcement
+			if err := json.Unmarshal(datagram, &got); err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Rooms) != tt.listed || got.OpenRooms != len(tt.rooms) {
+				t.Errorf("listed %d of %d rooms, want %d of %d", len(got.Rooms), got.OpenRooms, tt.listed, len(tt.rooms))
+			}
+		})
+	}
+}
@@ -0,0 +1,433 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Export format identifiers accepted by the export subcommand
+const (
+	ExportCSV      = "csv"
+	ExportJSON     = "json"
+	ExportMarkdown = "markdown"
+
+	exportDateLayout = "2006-01-02" // Date format for -from and -to filters
+)
+
+/*
+ExportFilter restricts which sessions are included in an export.
+
+Zero values disable a filter: an empty Player matches everyone and zero
+times leave the date range open-ended.
+*/
+type ExportFilter struct {
+	From   time.Time `json:"from,omitempty"`   // Inclusive start of the date range
+	To     time.Time `json:"to,omitempty"`     // Exclusive end of the date range
+	Player string    `json:"player,omitempty"` // Only sessions this player took part in
+}
+
+/*
+PlayerStats summarizes one player's performance within an export.
+*/
+type PlayerStats struct {
+	Name            string  `json:"name"`
+	Games           int     `json:"games"`
+	Wins            int     `json:"wins"`
+	WinRate         float64 `json:"win_rate"`         // Percentage of games won
+	TotalScore      int     `json:"total_score"`      // Sum of winning scores
+	AverageScore    float64 `json:"average_score"`    // Points per game played
+	BestScore       int     `json:"best_score"`       // Highest single-game score
+	AverageAttempts float64 `json:"average_attempts"` // Mean attempts in games played
+	Achievements    int     `json:"achievements"`     // Number of unlocked achievements
+}
+
+/*
+ExportReport is the complete data set written by every export format.
+*/
+type ExportReport struct {
+	GeneratedAt time.Time          `json:"generated_at"`
+	Filter      ExportFilter       `json:"filter"`
+	History     []GameSession      `json:"history"`
+	Leaderboard []LeaderboardEntry `json:"leaderboard"`
+	Players     []PlayerStats      `json:"players"`
+}
+
+/*
+matches reports whether a session passes every active filter.
+*/
+func (f ExportFilter) matches(session GameSession) bool {
+	if !f.From.IsZero() && session.Timestamp.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !session.Timestamp.Before(f.To) {
+		return false
+	}
+	if f.Player != "" && !contains(sessionPlayers(session), f.Player) {
+		return false
+	}
+	return true
+}
+
+/*
+buildExportReport filters the saved data and derives the leaderboard and
+per-player statistics from the matching sessions.
+
+Parameters:
+- data *SaveData: Loaded save file
+- filter ExportFilter: Date-range and player restrictions
+- now time.Time: Report generation timestamp
+
+Returns:
+- *ExportReport: Report ready to be written in any format
+*/
+func buildExportReport(data *SaveData, filter ExportFilter, now time.Time) *ExportReport {
+	report := &ExportReport{GeneratedAt: now, Filter: filter}
+	for _, session := range data.GameHistory {
+		if filter.matches(session) {
+			report.History = append(report.History, session)
+		}
+	}
+
+	// The leaderboard is computed from the filtered history so it always
+	// agrees with the exported sessions (validated query, cannot fail)
+	report.Leaderboard, _ = queryLeaderboard(report.History, LeaderboardQuery{}, now)
+
+	attempts := make(map[string]int)
+	for _, session := range report.History {
+		for _, player := range sessionPlayers(session) {
+			attempts[player] += session.Attempts
+		}
+	}
+
+	for _, entry := range report.Leaderboard {
+		if filter.Player != "" && entry.Name != filter.Player {
+			continue
+		}
+		stats := PlayerStats{
+			Name:         entry.Name,
+			Games:        entry.Games,
+			Wins:         entry.Wins,
+			TotalScore:   entry.TotalScore,
+			AverageScore: entry.AverageScore,
+			BestScore:    entry.BestScore,
+			Achievements: len(data.Achievements[entry.Name]),
+		}
+		if entry.Games > 0 {
+			stats.WinRate = float64(entry.Wins) / float64(entry.Games) * 100
+			stats.AverageAttempts = float64(attempts[entry.Name]) / float64(entry.Games)
+		}
+		report.Players = append(report.Players, stats)
+	}
+	sort.Slice(report.Players, func(i, j int) bool {
+		return report.Players[i].Name < report.Players[j].Name
+	})
+
+	return report
+}
+
+/*
+runExportCommand writes history, leaderboard and player statistics to CSV,
+JSON or Markdown.
+
+Usage:
+
+	guessing-game export -format csv|json|markdown [-out path] [-from 2026-01-01] [-to 2026-01-31] [-player Alice]
+
+CSV output produces one file per table (history.csv, leaderboard.csv and
+players.csv) inside the -out directory; JSON and Markdown produce a single
+file, or write to standard output when -out is "-".
+
+Parameters:
+- args []string: Subcommand arguments
+
+Returns:
+- int: Process exit code
+*/
+func runExportCommand(args []string) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flags.String("format", ExportMarkdown, "output format: csv, json or markdown")
+	out := flags.String("out", "", "output file (json/markdown, '-' for stdout) or directory (csv)")
+	from := flags.String("from", "", "only include games on or after this date (YYYY-MM-DD)")
+	to := flags.String("to", "", "only include games on or before this date (YYYY-MM-DD)")
+	player := flags.String("player", "", "only include games this player took part in")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	filter := ExportFilter{Player: strings.TrimSpace(*player)}
+	var err error
+	if filter.From, err = parseExportDate(*from); err != nil {
+		printColoredMessage(fmt.Sprintf("Invalid -from date: %v", err), ColorRed)
+		return 2
+	}
+	if filter.To, err = parseExportDate(*to); err != nil {
+		printColoredMessage(fmt.Sprintf("Invalid -to date: %v", err), ColorRed)
+		return 2
+	}
+	if !filter.To.IsZero() {
+		filter.To = filter.To.AddDate(0, 0, 1) // Make the end date inclusive
+	}
+
+	data := loadSaveDataOrReport()
+	if data == nil {
+		return 1
+	}
+	report := buildExportReport(data, filter, time.Now())
+
+	switch strings.ToLower(*format) {
+	case ExportCSV:
+		dir := *out
+		if dir == "" {
+			dir = "guessing-game-export"
+		}
+		err = writeExportCSV(dir, report)
+		*out = dir
+	case ExportJSON:
+		err = writeExportFile(*out, "guessing-game-export.json", report, writeExportJSON)
+	case ExportMarkdown, "md":
+		err = writeExportFile(*out, "guessing-game-report.md", report, writeExportMarkdown)
+	default:
+		printColoredMessage(fmt.Sprintf("Unknown export format %q (want csv, json or markdown).", *format), ColorRed)
+		return 2
+	}
+
+	if err != nil {
+		printColoredMessage(fmt.Sprintf("Export failed: %v", err), ColorRed)
+		return 1
+	}
+	if *out != "-" {
+		printColoredMessage(fmt.Sprintf("Exported %d games for %d players.", len(report.History), len(report.Players)), ColorGreen)
+	}
+	return 0
+}
+
+/*
+parseExportDate parses an optional YYYY-MM-DD date in the local time zone.
+
+Returns:
+- time.Time: Midnight of the given date, or the zero time for empty input
+- error: Parse failure
+*/
+func parseExportDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation(exportDateLayout, value, time.Local)
+}
+
+/*
+writeExportFile opens the destination for a single-file export and runs the
+format writer against it.
+
+Parameters:
+- path string: Destination path, "-" for stdout, or empty for defaultName
+- defaultName string: File name used when no path was given
+- report *ExportReport: Data to write
+- write func(io.Writer, *ExportReport) error: Format-specific writer
+*/
+func writeExportFile(path, defaultName string, report *ExportReport, write func(io.Writer, *ExportReport) error) error {
+	if path == "-" {
+		return write(os.Stdout, report)
+	}
+	if path == "" {
+		path = defaultName
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(file, report); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+/*
+writeExportJSON writes the report as indented JSON.
+*/
+func writeExportJSON(w io.Writer, report *ExportReport) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+/*
+writeExportCSV writes one CSV file per report table into a directory.
+
+Files Written:
+- history.csv: One row per game session
+- leaderboard.csv: Ranked leaderboard for the filtered sessions
+- players.csv: Per-player statistics
+*/
+func writeExportCSV(dir string, report *ExportReport) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	history := [][]string{{"timestamp", "difficulty", "max_range", "time_limit_seconds", "winner", "players", "attempts", "duration_seconds", "final_score"}}
+	for _, session := range report.History {
+		maxRange, timeLimit := "", ""
+		if session.MaxRange > 0 {
+			maxRange = strconv.Itoa(session.MaxRange)
+		} else if session.Difficulty != DifficultyAdaptive {
+			maxRange = strconv.Itoa(getMaxRange(session.Difficulty)) // Recorded before ranges were stored
+		}
+		if session.TimeLimit > 0 {
+			timeLimit = strconv.FormatFloat(session.TimeLimit.Seconds(), 'f', 1, 64)
+		}
+		history = append(history, []string{
+			session.Timestamp.Format(time.RFC3339),
+			session.Difficulty,
+			maxRange,
+			timeLimit,
+			session.Winner,
+			strings.Join(sessionPlayers(session), ";"),
+			strconv.Itoa(session.Attempts),
+			strconv.FormatFloat(session.Duration.Seconds(), 'f', 1, 64),
+			strconv.Itoa(session.FinalScore),
+		})
+	}
+
+	leaderboard := [][]string{{"rank", "player", "games", "wins", "total_score", "average_score", "best_score"}}
+	for i, entry := range report.Leaderboard {
+		leaderboard = append(leaderboard, []string{
+			strconv.Itoa(i + 1),
+			entry.Name,
+			strconv.Itoa(entry.Games),
+			strconv.Itoa(entry.Wins),
+			strconv.Itoa(entry.TotalScore),
+			strconv.FormatFloat(entry.AverageScore, 'f', 1, 64),
+			strconv.Itoa(entry.BestScore),
+		})
+	}
+
+	players := [][]string{{"player", "games", "wins", "win_rate", "total_score", "average_score", "best_score", "average_attempts", "achievements"}}
+	for _, stats := range report.Players {
+		players = append(players, []string{
+			stats.Name,
+			strconv.Itoa(stats.Games),
+			strconv.Itoa(stats.Wins),
+			strconv.FormatFloat(stats.WinRate, 'f', 1, 64),
+			strconv.Itoa(stats.TotalScore),
+			strconv.FormatFloat(stats.AverageScore, 'f', 1, 64),
+			strconv.Itoa(stats.BestScore),
+			strconv.FormatFloat(stats.AverageAttempts, 'f', 1, 64),
+			strconv.Itoa(stats.Achievements),
+		})
+	}
+
+	tables := map[string][][]string{
+		"history.csv":     history,
+		"leaderboard.csv": leaderboard,
+		"players.csv":     players,
+	}
+	for name, rows := range tables {
+		if err := writeCSVFile(filepath.Join(dir, name), rows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+writeCSVFile writes rows to a single CSV file.
+*/
+func writeCSVFile(path string, rows [][]string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(file)
+	if err := writer.WriteAll(rows); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+/*
+writeExportMarkdown writes a human-readable weekly-summary style report.
+
+The report is plain GitHub-flavored Markdown without ANSI colors so it can
+be pasted straight into chat tools and wikis.
+*/
+func writeExportMarkdown(w io.Writer, report *ExportReport) error {
+	var b strings.Builder
+
+	b.WriteString("# Number Guessing Game Report\n\n")
+	fmt.Fprintf(&b, "_Generated %s_\n\n", report.GeneratedAt.Format("2006-01-02 15:04"))
+
+	var filters []string
+	if !report.Filter.From.IsZero() {
+		filters = append(filters, "from "+report.Filter.From.Format(exportDateLayout))
+	}
+	if !report.Filter.To.IsZero() {
+		filters = append(filters, "to "+report.Filter.To.AddDate(0, 0, -1).Format(exportDateLayout))
+	}
+	if report.Filter.Player != "" {
+		filters = append(filters, "player "+report.Filter.Player)
+	}
+	if len(filters) > 0 {
+		fmt.Fprintf(&b, "Filters: %s\n\n", strings.Join(filters, ", "))
+	}
+
+	fmt.Fprintf(&b, "## Summary\n\n- Games played: %d\n- Players: %d\n\n", len(report.History), len(report.Players))
+
+	b.WriteString("## Leaderboard\n\n")
+	if len(report.Leaderboard) == 0 {
+		b.WriteString("No games recorded.\n\n")
+	} else {
+		b.WriteString("| Rank | Player | Games | Wins | Total | Average | Best |\n")
+		b.WriteString("|-----:|--------|------:|-----:|------:|--------:|-----:|\n")
+		for i, entry := range report.Leaderboard {
+			fmt.Fprintf(&b, "| %d | %s | %d | %d | %d | %.1f | %d |\n",
+				i+1, markdownEscape(entry.Name), entry.Games, entry.Wins,
+				entry.TotalScore, entry.AverageScore, entry.BestScore)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Player Statistics\n\n")
+	if len(report.Players) > 0 {
+		b.WriteString("| Player | Games | Win Rate | Avg Attempts | Achievements |\n")
+		b.WriteString("|--------|------:|---------:|-------------:|-------------:|\n")
+		for _, stats := range report.Players {
+			fmt.Fprintf(&b, "| %s | %d | %.1f%% | %.1f | %d |\n",
+				markdownEscape(stats.Name), stats.Games, stats.WinRate, stats.AverageAttempts, stats.Achievements)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Game History\n\n")
+	if len(report.History) > 0 {
+		b.WriteString("| Date | Difficulty | Winner | Attempts | Duration | Score |\n")
+		b.WriteString("|------|------------|--------|---------:|---------:|------:|\n")
+		for _, session := range report.History {
+			fmt.Fprintf(&b, "| %s | %s | %s | %d | %s | %d |\n",
+				session.Timestamp.Format("2006-01-02 15:04"), strings.Title(session.Difficulty),
+				markdownEscape(session.Winner), session.Attempts,
+				session.Duration.Round(time.Second), session.FinalScore)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownEscape prevents player names from breaking table layout
+func markdownEscape(text string) string {
+	return strings.ReplaceAll(text, "|", "\\|")
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+External bots are programs, written in any language, that play through a
+line-based text protocol on their standard input and output - much like
+UCI for chess engines.
+
+The game starts the program once per game session. Every line is a command
+word followed by space-separated arguments; player names always come last
+because they may contain spaces.
+
+Game to bot:
+
+	hello 1                             Protocol version; answer with "ready"
+	newgame <max_range> <time_ms> <me>  A game starts; the secret is in 1..max_range, <me> is the bot's name
+	result <value> <outcome> <player>   A turn ended: outcome is low, high, correct, invalid or timeout
+	go <low> <high> <time_ms>           Your turn: the target is in low..high; answer within time_ms
+	quit                                The game is over; exit
+
+Bot to game:
+
+	name <display name>                 Optional, before "ready": the name shown to players
+	ready                               Handshake complete
+	guess <n>                           Answer to "go"
+	info <text>                         Free-form diagnostics, written to the debug log
+
+A bot that does not answer "go" in time loses the turn; a late guess is
+discarded. Anything written to standard error is ignored.
+*/
+
+// External bot protocol constants
+const (
+	BotProtocolVersion = 1
+	BotStartTimeout    = 5 * time.Second // Time a bot has to answer "hello"
+	BotPrefix          = "exec"          // "bot exec <command>" registers an external bot
+)
+
+// ErrBotProtocol is returned when a bot program does not follow the protocol
+var ErrBotProtocol = errors.New("bot protocol error")
+
+/*
+BotObserver is implemented by bots that follow the whole game: they are
+told when it starts and see every turn, their own and other players', as
+it is recorded.
+*/
+type BotObserver interface {
+	NewGame(maxRange int, timeLimit time.Duration, self string)
+	Observe(player string, result TurnResult)
+}
+
+/*
+beginBotGame tells the session's observing bots that the game is starting.
+*/
+func beginBotGame(gameState *GameState) {
+	for name, bot := range gameState.Bots {
+		if observer, ok := bot.(BotObserver); ok {
+			observer.NewGame(gameState.MaxRange, gameState.TimeLimit, name)
+		}
+	}
+}
+
+/*
+showTurnToBots forwards a recorded turn to the session's observing bots.
+*/
+func showTurnToBots(gameState *GameState, player string, result TurnResult) {
+	for _, bot := range gameState.Bots {
+		if observer, ok := bot.(BotObserver); ok {
+			observer.Observe(player, result)
+		}
+	}
+}
+
+/*
+closeBots shuts down bots that hold resources, such as external programs.
+*/
+func closeBots(gameState *GameState) {
+	for _, bot := range gameState.Bots {
+		if closer, ok := bot.(io.Closer); ok {
+			closer.Close()
+		}
+	}
+}
+
+/*
+externalBot is a Bot played by a subprocess speaking the external bot
+protocol.
+*/
+type externalBot struct {
+	name  string
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	lines chan string   // Lines the bot printed, closed when it exits
+	done  chan struct{} // Closed by Close; the reader stops delivering lines
+
+	mu sync.Mutex // Serializes writes to stdin
+}
+
+/*
+startExternalBot launches a bot program and performs the handshake.
+
+Parameters:
+- command []string: Program and arguments
+- timeout time.Duration: Time allowed for the handshake
+
+Returns:
+- *externalBot: Running bot, ready for "newgame"
+- error: Start failure, or ErrBotProtocol if the handshake fails
+*/
+func startExternalBot(command []string, timeout time.Duration) (*externalBot, error) {
+	if len(command) == 0 {
+		return nil, fmt.Errorf("%w: no command given", ErrBotProtocol)
+	}
+
+	cmd := exec.Command(command[0], command[1:]...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	bot := &externalBot{
+		name:  strings.TrimSuffix(filepath.Base(command[0]), filepath.Ext(command[0])),
+		cmd:   cmd,
+		stdin: stdin,
+		lines: make(chan string, 16),
+		done:  make(chan struct{}),
+	}
+	go func() {
+		defer close(bot.lines)
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			select {
+			case bot.lines <- strings.TrimSpace(scanner.Text()):
+			case <-bot.done:
+				return
+			}
+		}
+	}()
+
+	bot.send("hello %d", BotProtocolVersion)
+	deadline := time.After(timeout)
+	for {
+		select {
+		case line, open := <-bot.lines:
+			if !open {
+				bot.Close()
+				return nil, fmt.Errorf("%w: %s exited during the handshake", ErrBotProtocol, command[0])
+			}
+			word, rest, _ := strings.Cut(line, " ")
+			switch word {
+			case "ready":
+				return bot, nil
+			case "name":
+				if name := sanitizeChat(rest); name != "" {
+					bot.name = name
+				}
+			default:
+				bot.handleInfo(word, rest)
+			}
+		case <-deadline:
+			bot.Close()
+			return nil, fmt.Errorf("%w: %s did not answer hello within %s", ErrBotProtocol, command[0], timeout)
+		}
+	}
+}
+
+/*
+Name returns the display name the bot asked for, or its program name.
+*/
+func (b *externalBot) Name() string {
+	return b.name
+}
+
+/*
+NewGame tells the bot a game is starting.
+
+Parameters:
+- maxRange int: Upper bound of the range
+- timeLimit time.Duration: Time allowed per guess
+- self string: The bot's name in this game
+*/
+func (b *externalBot) NewGame(maxRange int, timeLimit time.Duration, self string) {
+	b.send("newgame %d %d %s", maxRange, timeLimit.Milliseconds(), self)
+}
+
+/*
+NextGuess asks the bot for a guess and waits for it until the time limit.
+
+Returns:
+- int: The guess (0 if the bot failed to answer)
+- time.Duration: How long the bot took; the time limit if it timed out
+*/
+func (b *externalBot) NextGuess(view BotView) (int, time.Duration) {
+	// Discard anything left over, such as a guess that arrived too late
+	for drained := false; !drained; {
+		select {
+		case line, open := <-b.lines:
+			if !open {
+				return 0, view.TimeLimit
+			}
+			word, rest, _ := strings.Cut(line, " ")
+			b.handleInfo(word, rest)
+		default:
+			drained = true
+		}
+	}
+
+	start := time.Now()
+	b.send("go %d %d %d", view.Low, view.High, view.TimeLimit.Milliseconds())
+	deadline := time.After(view.TimeLimit)
+	for {
+		select {
+		case line, open := <-b.lines:
+			if !open {
+				return 0, view.TimeLimit // The bot exited; every turn from now on times out
+			}
+			word, rest, _ := strings.Cut(line, " ")
+			if word != "guess" {
+				b.handleInfo(word, rest)
+				continue
+			}
+			guess, err := strconv.Atoi(strings.TrimSpace(rest))
+			if err != nil {
+				slog.Warn("bot sent an unreadable guess", "bot", b.name, "line", line)
+				return 0, time.Since(start)
+			}
+			return guess, time.Since(start)
+		case <-deadline:
+			return 0, view.TimeLimit
+		}
+	}
+}
+
+/*
+Observe forwards a recorded turn to the bot.
+*/
+func (b *externalBot) Observe(player string, result TurnResult) {
+	outcome := "invalid"
+	switch {
+	case result.TimedOut:
+		outcome = "timeout"
+	case result.Correct:
+		outcome = "correct"
+	case !result.Valid:
+	case strings.HasPrefix(result.Hint, "Too low"):
+		outcome = "low"
+	case strings.HasPrefix(result.Hint, "Too high"):
+		outcome = "high"
+	}
+	b.send("result %d %s %s", result.Value, outcome, player)
+}
+
+/*
+Close tells the bot to quit and waits briefly for it to exit.
+
+The bot's output is read to the end before cmd.Wait, which closes the pipe
+the reader goroutine is still reading; a bot that does not exit within a
+second is killed. Close must be called at most once.
+*/
+func (b *externalBot) Close() error {
+	b.send("quit")
+	b.stdin.Close()
+
+	if !b.drainLines(time.Second) {
+		b.cmd.Process.Kill()
+		b.drainLines(time.Second) // Output may outlive the bot if a child process holds it
+	}
+	close(b.done) // Frees the reader if it is still blocked
+	return b.cmd.Wait()
+}
+
+/*
+drainLines discards the bot's output until it ends.
+
+Returns:
+- bool: False if the output was still open after timeout
+*/
+func (b *externalBot) drainLines(timeout time.Duration) bool {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case _, open := <-b.lines:
+			if !open {
+				return true
+			}
+		case <-deadline:
+			return false
+		}
+	}
+}
+
+// send writes one protocol line; a bot that has exited simply stops hearing
+func (b *externalBot) send(format string, args ...any) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	fmt.Fprintf(b.stdin, format+"\n", args...)
+}
+
+// handleInfo logs diagnostics and unexpected lines from the bot
+func (b *externalBot) handleInfo(word, rest string) {
+	if word == "info" {
+		slog.Debug("bot info", "bot", b.name, "text", rest)
+	} else if word != "" {
+		slog.Debug("unexpected line from bot", "bot", b.name, "line", strings.TrimSpace(word+" "+rest))
+	}
+}
@@ -115,22 +115,31 @@ Memory Layout Considerations:
 */
 type GameState struct {
 	// Core game configuration - Immutable after initialization
+	SessionID  string        // Identifies the session in structured logs (room code for network games)
 	Difficulty string        // Current difficulty level (easy/medium/hard)
 	Target     int           // The secret number players must guess
 	MaxRange   int           // Upper bound for valid guesses
 	TimeLimit  time.Duration // Maximum time allowed per guess
 
 	// Player management - Dynamic collections requiring efficient access
-	Players []string       // Ordered list of player names for turn management
-	Scores  map[string]int // Current game scores indexed by player name
+	Players   []string            // Ordered list of player names for turn management
+	Scores    map[string]int      // Current game scores indexed by player name
+	Bots      map[string]Bot      // Computer players by name; humans have no entry
+	Handicaps map[string]Handicap // Per-player handicaps; players without one have no entry
 
 	// Game progress tracking - Mutable state updated during gameplay
-	StartTime time.Time // Game session start timestamp for duration calculation
-	Attempts  int       // Total number of guesses made across all players
+	StartTime time.Time     // Game session start timestamp for duration calculation
+	EndTime   time.Time     // Moment the winning guess was made (zero while playing)
+	Attempts  int           // Total number of guesses made across all players
+	GuessLog  []GuessRecord // Every turn taken this session, in order
+
+	// Session outcomes - Computed once the game has been won
+	NewAchievements map[string][]UnlockedAchievement // Achievements unlocked this session by player
 
 	// Persistent data - Survives across game sessions
-	Leaderboard map[string]int // All-time scores accumulated across multiple games
-	GameHistory []GameSession  // Historical game data for analytics
+	Leaderboard  map[string]int                   // All-time scores accumulated across multiple games
+	GameHistory  []GameSession                    // Historical game data for analytics
+	Achievements map[string][]UnlockedAchievement // Previously unlocked achievements by player
 }
 
 /*
@@ -145,13 +154,19 @@ Data Retention Strategy:
 - Immutable once created to prevent historical data corruption
 */
 type GameSession struct {
-	Difficulty  string        // Difficulty level for this session
-	Winner      string        // Name of the winning player
-	Attempts    int           // Total attempts made during the game
-	Duration    time.Duration // Total time from start to completion
-	PlayerCount int           // Number of players who participated
-	FinalScore  int           // Winner's final score
-	Timestamp   time.Time     // When this game session completed
+	Difficulty         string        `json:"difficulty"`                    // Difficulty level for this session
+	Winner             string        `json:"winner"`                        // Name of the winning player
+	Players            []string      `json:"players"`                       // Everyone who took part, in turn order
+	Attempts           int           `json:"attempts"`                      // Total attempts made during the game
+	WinnerTurns        int           `json:"winner_turns,omitempty"`        // Turns the winner took (0 in sessions recorded before this was tracked)
+	Duration           time.Duration `json:"duration"`                      // Total time from start to completion
+	MaxRange           int           `json:"max_range,omitempty"`           // Upper bound of the range played (chosen per game when adaptive)
+	TimeLimit          time.Duration `json:"time_limit,omitempty"`          // Time allowed per guess
+	PlayerCount        int           `json:"player_count"`                  // Number of players who participated
+	FinalScore         int           `json:"final_score"`                   // Winner's final score, handicap multiplier included
+	UnhandicappedScore int           `json:"unhandicapped_score,omitempty"` // Winner's score before their handicap multiplier (0 without one)
+	Handicap           string        `json:"handicap,omitempty"`            // Winner's handicap in parseHandicap notation (empty without one)
+	Timestamp          time.Time     `json:"timestamp"`                     // When this game session completed
 }
 
 /*
@@ -166,10 +181,24 @@ Error Handling Philosophy:
 - Extensible design supports future validation rules
 */
 type TurnResult struct {
-	Correct bool   // True if the guess matches the target number exactly
-	Valid   bool   // True if the input was properly formatted and within range
-	Hint    string // Contextual feedback message for the player
-	Value   int    // The actual numeric value guessed (for logging/analytics)
+	Correct  bool   // True if the guess matches the target number exactly
+	Valid    bool   // True if the input was properly formatted and within range
+	TimedOut bool   // True if the player ran out of time before answering
+	Hint     string // Contextual feedback message for the player
+	Value    int    // The actual numeric value guessed (for logging/analytics)
+}
+
+/*
+GuessRecord captures a single turn in the session's guess log.
+
+The guess log is the raw material for post-game analysis such as achievement
+evaluation, where the order and timing of individual guesses matters.
+*/
+type GuessRecord struct {
+	Player       string        // Player who took the turn
+	Result       TurnResult    // Outcome of the turn
+	Elapsed      time.Duration // Time since the session started when the turn ended
+	TurnDuration time.Duration // Time the player spent on this turn
 }
 
 /*
@@ -215,11 +244,34 @@ func main() {
 	// being deterministic enough for debugging when needed
 	rand.Seed(time.Now().UnixNano())
 
-	// Initialize persistent cross-session data structures
+	// Structured logs go wherever GUESSING_GAME_LOG says, never mixed into
+	// the game's own output unless asked for
+	closeLog, err := setupLogging()
+	if err != nil {
+		printColoredMessage(fmt.Sprintf("Logging disabled: %v", err), ColorYellow)
+	}
+	defer closeLog()
+
+	// Subcommands (leaderboard, help, ...) run non-interactively and exit
+	if len(os.Args) > 1 {
+		code := runCommand(os.Args[1:])
+		closeLog() // os.Exit skips deferred calls
+		os.Exit(code)
+	}
+
+	// Load persistent cross-session data structures from the save file
 	// These maps survive across individual game sessions to provide
 	// comprehensive player analytics and historical tracking
-	leaderboard := make(map[string]int)
-	var gameHistory []GameSession
+	dataPath := dataFilePath()
+	saveData, err := loadSaveData(dataPath)
+	if err != nil {
+		// A corrupt save must not prevent playing; start fresh in memory
+		printColoredMessage(fmt.Sprintf("Could not load saved data (%v). Starting fresh.", err), ColorYellow)
+		saveData = newSaveData()
+	}
+	leaderboard := saveData.Leaderboard
+	gameHistory := saveData.GameHistory
+	achievements := saveData.Achievements
 
 	// Display enhanced welcome banner with colored formatting
 	printColoredHeader(" Ultimate Number Guessing Game - Enhanced Edition ")
@@ -241,10 +293,11 @@ func main() {
 		// Initialize new game state for each session
 		// Clean slate approach prevents state leakage between games
 		gameState := &GameState{
-			TimeLimit:   DefaultTimeLimit,
-			Leaderboard: leaderboard,
-			GameHistory: gameHistory,
-			Scores:      make(map[string]int),
+			TimeLimit:    DefaultTimeLimit,
+			Leaderboard:  leaderboard,
+			GameHistory:  gameHistory,
+			Achievements: achievements,
+			Scores:       make(map[string]int),
 		}
 
 		// Execute complete game session
@@ -252,11 +305,17 @@ func main() {
 
 		// Update persistent data structures with current session results
 		// This operation is atomic to prevent data corruption
-		updatePersistentData(gameState, &leaderboard, &gameHistory)
+		updatePersistentData(gameState, &leaderboard, &gameHistory, achievements)
+		saveData.Leaderboard = leaderboard
+		saveData.GameHistory = gameHistory
+		saveData.Achievements = achievements
+		if err := saveSaveData(dataPath, saveData); err != nil {
+			printColoredMessage(fmt.Sprintf("Could not save game data: %v", err), ColorYellow)
+		}
 
 		// Prompt for session continuation with enhanced UI
 		if !promptRestart() {
-			displayFinalStatistics(leaderboard, gameHistory)
+			displayFinalStatistics(leaderboard, gameHistory, achievements)
 			printColoredMessage("Thank you for playing! May your future guesses be ever accurate! ", ColorGreen)
 			break
 		}
@@ -297,19 +356,51 @@ func runGameSession(gameState *GameState) {
 	// Phase 1: Game Configuration
 	// Collect and validate all user preferences before game initialization
 	gameState.Difficulty = selectDifficulty()
-	gameState.Target = generateNumber(gameState.Difficulty)
-	gameState.Players = getPlayers()
-	gameState.MaxRange = getMaxRange(gameState.Difficulty)
+	gameState.Players, gameState.Bots = getPlayers()
+	var adaptive AdaptiveParams
+	if gameState.Difficulty == DifficultyAdaptive {
+		adaptive = applyAdaptiveDifficulty(gameState)
+	} else {
+		gameState.Target = generateNumber(gameState.Difficulty)
+		gameState.MaxRange = getMaxRange(gameState.Difficulty)
+	}
+	gameState.Handicaps = selectHandicaps(gameState)
 	gameState.StartTime = time.Now()
+	gameState.SessionID = newToken(4)
+
+	logger := sessionLogger(gameState)
+	logger.Info("session started", "mode", "local", "players", gameState.Players,
+		"max_range", gameState.MaxRange, "time_limit", gameState.TimeLimit)
+	if gameState.Difficulty == DifficultyAdaptive {
+		logger.Info("adaptive difficulty", "skill", adaptive.Skill, "history_games", adaptive.Games)
+	}
+	for player, handicap := range gameState.Handicaps {
+		logger.Info("handicap", "player", player, "handicap", handicap.String())
+	}
+	logger.Debug("target chosen", "target", gameState.Target)
+	beginBotGame(gameState)
+	defer closeBots(gameState)
 
 	// Display game initialization summary with enhanced formatting
 	printColoredHeader("🚀 Game Session Initialized")
 	fmt.Printf("%sDifficulty:%s %s (Range: 1-%d)\n",
 		ColorBlue, ColorReset, strings.Title(gameState.Difficulty), gameState.MaxRange)
 	fmt.Printf("%sPlayers:%s %s\n",
-		ColorBlue, ColorReset, strings.Join(gameState.Players, ", "))
+		ColorBlue, ColorReset, describePlayers(gameState))
 	fmt.Printf("%sTime Limit:%s %s per guess\n",
 		ColorBlue, ColorReset, gameState.TimeLimit)
+	if gameState.Difficulty == DifficultyAdaptive {
+		fmt.Printf("%sAdaptive:%s skill %.2f from %d recent games (1.00 is average)\n",
+			ColorBlue, ColorReset, adaptive.Skill, adaptive.Games)
+	}
+	if len(gameState.Handicaps) > 0 {
+		fmt.Printf("%sHandicaps:%s\n", ColorBlue, ColorReset)
+		for _, player := range gameState.Players {
+			if handicap, ok := gameState.Handicaps[player]; ok {
+				fmt.Printf("  %s: %s\n", player, describeHandicap(handicap))
+			}
+		}
+	}
 
 	printSeparator()
 
@@ -317,26 +408,49 @@ func runGameSession(gameState *GameState) {
 	// Continue until a player successfully guesses the target number
 	gameWon := false
 	reader := bufio.NewReader(os.Stdin)
+	low, high := 1, gameState.MaxRange // Feasible range, shown to computer players
+	hintsLeft := make(map[string]int)
+	for player, handicap := range gameState.Handicaps {
+		hintsLeft[player] = handicap.BonusHints
+	}
 
 	for !gameWon {
 		// Iterate through all players for each round
 		// This ensures fair turn distribution and prevents any player advantage
 		for _, player := range gameState.Players {
 			// Handle individual player turn with timeout and validation
-			guessResult := handlePlayerTurn(player, gameState, reader)
-			gameState.Attempts++
-
-			// Check for winning condition
-			if guessResult.Correct {
-				// Calculate final score using sophisticated algorithm
-				elapsedTime := time.Since(gameState.StartTime)
-				gameState.Scores[player] = calculateScore(
-					gameState.Attempts,
-					gameState.Difficulty,
-					elapsedTime,
-				)
+			turnStart := time.Now()
+			timeLimit := gameState.TimeLimit + gameState.Handicaps[player].ExtraTime
+
+			// Handicapped players may start their turn with a bonus hint. Everyone
+			// shares the terminal, so the hint is public and narrows the range for
+			// all; the handicapped player's edge is getting to use it first
+			if hintsLeft[player] > 0 && high > low {
+				hintsLeft[player]--
+				low, high = bonusHint(low, high, gameState.Target)
+				printColoredMessage(fmt.Sprintf("💡 Bonus hint for %s: the number is between %d and %d (%d left)",
+					player, low, high, hintsLeft[player]), ColorPurple)
+			}
+
+			var guessResult TurnResult
+			if bot := gameState.Bots[player]; bot != nil {
+				guessResult = handleBotTurn(player, bot, gameState, BotView{
+					MaxRange:  gameState.MaxRange,
+					TimeLimit: timeLimit,
+					Low:       low,
+					High:      high,
+					Attempts:  gameState.Attempts,
+				})
+			} else {
+				guessResult = handlePlayerTurn(player, gameState, reader, timeLimit)
+			}
+			low, high = narrowRange(low, high, gameState.Target, guessResult)
+			showTurnToBots(gameState, player, guessResult)
 
+			// Record the turn and check for winning condition
+			if recordTurn(gameState, player, guessResult, time.Since(turnStart)) {
 				// Display victory announcement with celebration formatting
+				elapsedTime := gameState.EndTime.Sub(gameState.StartTime)
 				printColoredMessage(fmt.Sprintf(" %s wins with %d attempts in %s! ",
 					player, gameState.Attempts, elapsedTime.Round(time.Second)), ColorGreen)
 
@@ -356,9 +470,66 @@ func runGameSession(gameState *GameState) {
 	}
 
 	// Phase 3: Post-Game Analysis and Display
+	gameState.NewAchievements = evaluateAchievements(gameState)
+	logger.Info("session finished", "attempts", gameState.Attempts,
+		"duration", gameState.EndTime.Sub(gameState.StartTime), "scores", gameState.Scores)
 	displayGameResults(gameState)
 }
 
+/*
+recordTurn applies a completed turn to the game state.
+
+This is the single place where turns change the score-relevant state, shared
+by the local hot-seat loop and the network server so both play by identical
+rules.
+
+State Updates:
+1. Attempt counter is incremented (invalid input and timeouts count too)
+2. The turn is appended to the guess log
+3. On a correct guess, the end time is fixed and the winner is scored
+
+Parameters:
+- gameState *GameState: Session being played
+- player string: Player who took the turn
+- result TurnResult: Outcome of the turn
+- turnDuration time.Duration: Time the player spent on the turn
+
+Returns:
+- bool: True if the turn won the game
+*/
+func recordTurn(gameState *GameState, player string, result TurnResult, turnDuration time.Duration) bool {
+	gameState.Attempts++
+	gameState.GuessLog = append(gameState.GuessLog, GuessRecord{
+		Player:       player,
+		Result:       result,
+		Elapsed:      time.Since(gameState.StartTime),
+		TurnDuration: turnDuration,
+	})
+
+	logger := sessionLogger(gameState)
+	if result.TimedOut {
+		logger.Info("turn timed out", "player", player, "attempt", gameState.Attempts,
+			"reason", result.Hint, "turn_duration", turnDuration)
+	} else {
+		logger.Info("turn", "player", player, "attempt", gameState.Attempts, "guess", result.Value,
+			"valid", result.Valid, "correct", result.Correct, "hint", result.Hint, "turn_duration", turnDuration)
+	}
+
+	if !result.Correct {
+		return false
+	}
+
+	// Calculate final score using sophisticated algorithm
+	gameState.EndTime = time.Now()
+	elapsed := gameState.EndTime.Sub(gameState.StartTime)
+	score := calculateScore(gameState.Attempts, scoringDifficulty(gameState), elapsed)
+	multiplier := gameState.Handicaps[player].scoreMultiplier()
+	gameState.Scores[player] = int(float64(score) * multiplier)
+	logger.Info("score awarded", "player", player, "score", gameState.Scores[player],
+		"attempts", gameState.Attempts, "elapsed", elapsed, "unhandicapped_score", score, "handicap_multiplier", multiplier)
+	return true
+}
+
 /*
 handlePlayerTurn manages a single player's turn with comprehensive input handling.
 
@@ -375,6 +546,7 @@ Parameters:
 - player string: Current player's display name
 - gameState *GameState: Reference to current game state
 - reader *bufio.Reader: Buffered input reader for efficient I/O
+- timeLimit time.Duration: Time allowed for this turn, including any handicap
 
 Returns:
 - TurnResult: Comprehensive result structure with validation status and feedback
@@ -390,7 +562,7 @@ Error Recovery:
 - Clear feedback helps users correct their mistakes
 - Timeout handling prevents indefinite blocking
 */
-func handlePlayerTurn(player string, gameState *GameState, reader *bufio.Reader) TurnResult {
+func handlePlayerTurn(player string, gameState *GameState, reader *bufio.Reader, timeLimit time.Duration) TurnResult {
 	// Display player prompt with enhanced formatting and context
 	fmt.Printf("%s[%s's Turn]%s Enter your guess (1-%d) or 'help': ",
 		ColorBlue, player, ColorReset, gameState.MaxRange)
@@ -428,49 +600,8 @@ func handlePlayerTurn(player string, gameState *GameState, reader *bufio.Reader)
 			return
 		}
 
-		// Convert string input to integer with comprehensive error handling
-		guess, err := strconv.Atoi(text)
-		if err != nil {
-			guessCh <- TurnResult{
-				Valid: false,
-				Hint:  "Please enter a valid number (digits only)",
-				Value: 0,
-			}
-			return
-		}
-
-		// Validate guess against target number and provide appropriate feedback
-		if guess == gameState.Target {
-			guessCh <- TurnResult{
-				Correct: true,
-				Valid:   true,
-				Value:   guess,
-			}
-		} else if guess < 1 || guess > gameState.MaxRange {
-			guessCh <- TurnResult{
-				Valid: false,
-				Hint:  fmt.Sprintf("Number must be between 1 and %d", gameState.MaxRange),
-				Value: guess,
-			}
-		} else if guess < gameState.Target {
-			// Calculate proximity hint for enhanced user experience
-			diff := gameState.Target - guess
-			proximityHint := getProximityHint(diff, gameState.MaxRange)
-			guessCh <- TurnResult{
-				Valid: true,
-				Hint:  fmt.Sprintf("Too low! %s", proximityHint),
-				Value: guess,
-			}
-		} else {
-			// Calculate proximity hint for high guesses
-			diff := guess - gameState.Target
-			proximityHint := getProximityHint(diff, gameState.MaxRange)
-			guessCh <- TurnResult{
-				Valid: true,
-				Hint:  fmt.Sprintf("Too high! %s", proximityHint),
-				Value: guess,
-			}
-		}
+		// Validate the guess against the target using the shared game rules
+		guessCh <- evaluateGuess(gameState, text)
 	}()
 
 	// Implement timeout mechanism using select statement
@@ -478,16 +609,133 @@ func handlePlayerTurn(player string, gameState *GameState, reader *bufio.Reader)
 	select {
 	case result := <-guessCh:
 		return result
-	case <-time.After(gameState.TimeLimit):
+	case <-time.After(timeLimit):
 		// Handle timeout gracefully with user-friendly messaging
 		printColoredMessage(fmt.Sprintf("Time's up, %s! Your turn is skipped.", player), ColorRed)
+		return TurnResult{
+			Valid:    false,
+			TimedOut: true,
+			Hint:     "Timeout - turn skipped",
+		}
+	}
+}
+
+/*
+handleBotTurn plays a computer player's turn.
+
+The bot's thinking time counts against the time limit just like a human's:
+a bot that thinks too long times out.
+
+Parameters:
+- player string: The bot's display name
+- bot Bot: The computer player
+- gameState *GameState: Reference to current game state
+- view BotView: What the bot knows about the game, including its time limit
+
+Returns:
+- TurnResult: Outcome of the bot's guess, or a timeout
+*/
+func handleBotTurn(player string, bot Bot, gameState *GameState, view BotView) TurnResult {
+	fmt.Printf("%s[%s's Turn]%s 🤖 thinking... ", ColorBlue, player, ColorReset)
+
+	start := time.Now()
+	guess, think := bot.NextGuess(view)
+	if think >= view.TimeLimit {
+		time.Sleep(view.TimeLimit - time.Since(start))
+		fmt.Println()
+		printColoredMessage(fmt.Sprintf("Time's up, %s! Your turn is skipped.", player), ColorRed)
+		return TurnResult{Valid: false, TimedOut: true, Hint: "Timeout - turn skipped"}
+	}
+	time.Sleep(think - time.Since(start))
+
+	fmt.Printf("%d\n", guess)
+	return evaluateGuess(gameState, strconv.Itoa(guess))
+}
+
+/*
+evaluateGuess validates a raw guess and compares it with the target number.
+
+The function is pure with respect to the game state - it never records the
+turn - so every front end (terminal, network server) can validate input the
+same way and then apply the outcome with recordTurn.
+
+Parameters:
+- gameState *GameState: Session providing the target and range
+- text string: Raw guess as typed by the player
+
+Returns:
+- TurnResult: Validation status, correctness and proximity hint
+*/
+func evaluateGuess(gameState *GameState, text string) TurnResult {
+	// Convert string input to integer with comprehensive error handling
+	guess, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil {
+		return TurnResult{
+			Valid: false,
+			Hint:  "Please enter a valid number (digits only)",
+			Value: 0,
+		}
+	}
+
+	// Validate guess against target number and provide appropriate feedback
+	if guess == gameState.Target {
+		return TurnResult{
+			Correct: true,
+			Valid:   true,
+			Value:   guess,
+		}
+	} else if guess < 1 || guess > gameState.MaxRange {
 		return TurnResult{
 			Valid: false,
-			Hint:  "Timeout - turn skipped",
+			Hint:  fmt.Sprintf("Number must be between 1 and %d", gameState.MaxRange),
+			Value: guess,
+		}
+	} else if guess < gameState.Target {
+		// Calculate proximity hint for enhanced user experience
+		diff := gameState.Target - guess
+		proximityHint := getProximityHint(diff, gameState.MaxRange)
+		return TurnResult{
+			Valid: true,
+			Hint:  fmt.Sprintf("Too low! %s", proximityHint),
+			Value: guess,
+		}
+	} else {
+		// Calculate proximity hint for high guesses
+		diff := guess - gameState.Target
+		proximityHint := getProximityHint(diff, gameState.MaxRange)
+		return TurnResult{
+			Valid: true,
+			Hint:  fmt.Sprintf("Too high! %s", proximityHint),
+			Value: guess,
 		}
 	}
 }
 
+/*
+narrowRange applies a turn's hint to the feasible range - the numbers a
+player who has seen every hint so far knows the target must be among.
+
+Parameters:
+- low, high int: Feasible range before the turn
+- target int: The secret number the hint was based on
+- result TurnResult: Outcome of the turn
+
+Returns:
+- int, int: Feasible range after the turn
+*/
+func narrowRange(low, high, target int, result TurnResult) (int, int) {
+	switch {
+	case !result.Valid:
+	case result.Correct:
+		low, high = result.Value, result.Value
+	case result.Value < target && result.Value >= low:
+		low = result.Value + 1
+	case result.Value > target && result.Value <= high:
+		high = result.Value - 1
+	}
+	return low, high
+}
+
 /*
 getProximityHint generates contextual proximity feedback based on guess accuracy.
 
@@ -561,12 +809,13 @@ func selectDifficulty() string {
 	fmt.Printf("  %s1. Easy%s   - Range: 1-%d (Beginner friendly)\n", ColorGreen, ColorReset, EasyMaxRange)
 	fmt.Printf("  %s2. Medium%s - Range: 1-%d (Balanced challenge)\n", ColorYellow, ColorReset, MediumMaxRange)
 	fmt.Printf("  %s3. Hard%s   - Range: 1-%d (Expert level)\n", ColorRed, ColorReset, HardMaxRange)
+	fmt.Printf("  %s4. Adaptive%s - Range and time limit fitted to the players' recent games\n", ColorPurple, ColorReset)
 
 	invalidAttempts := 0
 	maxInvalidAttempts := 5 // Prevent infinite loops from persistent invalid input
 
 	for invalidAttempts < maxInvalidAttempts {
-		fmt.Print("Enter your choice (easy/medium/hard or 1/2/3): ")
+		fmt.Print("Enter your choice (easy/medium/hard/adaptive or 1/2/3/4): ")
 		var choice string
 		fmt.Scan(&choice)
 
@@ -581,6 +830,8 @@ func selectDifficulty() string {
 			return "medium"
 		case "hard", "3", "h":
 			return "hard"
+		case DifficultyAdaptive, "4", "a":
+			return DifficultyAdaptive
 		case "help":
 			displayDifficultyHelp()
 			continue // Don't count help requests as invalid attempts
@@ -679,8 +930,15 @@ Data Structure Design:
 - Efficient duplicate checking using linear search
 - Memory-efficient storage for typical game sizes
 
+Computer Players:
+Typing "bot" instead of a name adds a computer player; "bot binary" picks
+its strategy directly (random, linear, binary or human), otherwise it is
+asked for. "bot exec <command>" runs an external bot program (see
+externalbot.go for its protocol).
+
 Returns:
 - []string: Validated and unique player names in turn order
+- map[string]Bot: Computer players among them, by name
 
 Validation Rules:
 - Player count must be within configured limits
@@ -688,9 +946,11 @@ Validation Rules:
 - Empty names are replaced with generated defaults
 - Whitespace is normalized to prevent formatting issues
 */
-func getPlayers() []string {
+func getPlayers() ([]string, map[string]Bot) {
 	reader := bufio.NewReader(os.Stdin)
 	var players []string
+	bots := make(map[string]Bot)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 
 	printColoredHeader("Player Registration")
 
@@ -702,7 +962,7 @@ func getPlayers() []string {
 	// Register each player with validation and conflict resolution
 	for i := 1; i <= numPlayers; i++ {
 		for {
-			fmt.Printf("Enter name for Player %d (or press Enter for default): ", i)
+			fmt.Printf("Enter name for Player %d (Enter for default, 'bot' for a computer player): ", i)
 			name, err := reader.ReadString('\n')
 			if err != nil {
 				// Handle input errors gracefully
@@ -711,6 +971,39 @@ func getPlayers() []string {
 				name = strings.TrimSpace(name)
 			}
 
+			// Computer players get a strategy and a generated name
+			fields := strings.Fields(name)
+			if len(fields) > 2 && strings.EqualFold(fields[0], "bot") && fields[1] == BotPrefix {
+				bot, err := startExternalBot(fields[2:], BotStartTimeout)
+				if err != nil {
+					printColoredMessage(fmt.Sprintf("Could not start bot: %v", err), ColorRed)
+					continue
+				}
+				name = bot.Name()
+				for n := 2; contains(players, name); n++ {
+					name = fmt.Sprintf("%s%d", bot.Name(), n)
+				}
+				bots[name] = bot
+			} else if len(fields) > 0 && strings.EqualFold(fields[0], "bot") {
+				strategy := ""
+				if len(fields) > 1 {
+					strategy = strings.ToLower(fields[1])
+				}
+				bot, err := newBot(strategy, rng)
+				for err != nil {
+					fmt.Printf("Choose a strategy (%s): ", strings.Join(BotStrategies, ", "))
+					line, readErr := reader.ReadString('\n')
+					if readErr != nil {
+						strategy = BotBinary
+					} else {
+						strategy = strings.ToLower(strings.TrimSpace(line))
+					}
+					bot, err = newBot(strategy, rng)
+				}
+				name = botName(strategy, players)
+				bots[name] = bot
+			}
+
 			// Generate default name for empty input
 			if name == "" {
 				name = fmt.Sprintf("Player%d", i)
@@ -727,7 +1020,22 @@ func getPlayers() []string {
 		}
 	}
 
-	return players
+	return players, bots
+}
+
+/*
+describePlayers lists the players in turn order, marking computer players
+with a robot.
+*/
+func describePlayers(gameState *GameState) string {
+	names := make([]string, len(gameState.Players))
+	for i, player := range gameState.Players {
+		names[i] = player
+		if gameState.Bots[player] != nil {
+			names[i] += " 🤖"
+		}
+	}
+	return strings.Join(names, ", ")
 }
 
 /*
@@ -834,15 +1142,22 @@ func calculateScore(attempts int, difficulty string, elapsedTime time.Duration)
 	}
 
 	// Apply difficulty-based multipliers for balanced competition
+	return int(float64(rawScore) * difficultyMultiplier(difficulty))
+}
+
+/*
+difficultyMultiplier returns the factor calculateScore applies to a
+difficulty's raw score. The balance report (balance.go) checks these
+against simulated play.
+*/
+func difficultyMultiplier(difficulty string) float64 {
 	switch difficulty {
-	case "easy":
-		return rawScore // No multiplier for easiest difficulty
 	case "medium":
-		return int(float64(rawScore) * 1.5) // 50% bonus for medium
+		return 1.5 // 50% bonus for medium
 	case "hard":
-		return rawScore * 2 // 100% bonus for hardest difficulty
+		return 2 // 100% bonus for hardest difficulty
 	default:
-		return rawScore // Default case for robustness
+		return 1 // No multiplier for easy, or unknown difficulties
 	}
 }
 
@@ -901,6 +1216,14 @@ func displayGameResults(gameState *GameState) {
 		}
 	}
 
+	// Announce any global records or personal bests broken this session
+	if session, completed := buildGameSession(gameState); completed {
+		announceRecordBreaks(findRecordBreaks(gameState.GameHistory, session))
+	}
+
+	// Announce achievements unlocked this session
+	announceAchievements(gameState.NewAchievements)
+
 	printSeparator()
 }
 
@@ -919,43 +1242,101 @@ Parameters:
 - gameState *GameState: Current session data
 - leaderboard *map[string]int: Persistent all-time scores
 - gameHistory *[]GameSession: Historical game records
+- achievements map[string][]UnlockedAchievement: Unlocked achievements by player
 
 Transaction Safety:
 - All updates complete successfully or none apply
 - Rollback capability for error conditions
 - Validation prevents invalid data persistence
 */
-func updatePersistentData(gameState *GameState, leaderboard *map[string]int, gameHistory *[]GameSession) {
+func updatePersistentData(gameState *GameState, leaderboard *map[string]int, gameHistory *[]GameSession,
+	achievements map[string][]UnlockedAchievement) {
 	// Update all-time leaderboard with current session scores
 	for player, score := range gameState.Scores {
 		(*leaderboard)[player] += score
 	}
 
 	// Create historical record of completed game session
-	if len(gameState.Scores) > 0 {
-		// Find winner (player with highest score in current session)
-		winner := ""
-		maxScore := -1
-		for player, score := range gameState.Scores {
-			if score > maxScore {
-				maxScore = score
-				winner = player
+	if session, completed := buildGameSession(gameState); completed {
+		*gameHistory = append(*gameHistory, session)
+	}
+
+	// Record achievements unlocked during this session. The game checked them
+	// against the save as it was when it began; another game may have saved
+	// the same achievement since, so skip any the save already holds
+	for player, unlocked := range gameState.NewAchievements {
+		for _, achievement := range unlocked {
+			if !hasAchievement(achievements, player, achievement.ID) {
+				achievements[player] = append(achievements[player], achievement)
 			}
 		}
+	}
+}
+
+/*
+buildGameSession creates the historical record for a finished game.
+
+Both the results screen (for record announcements) and the persistence layer
+use this function, guaranteeing they reason about identical session data.
+
+Parameters:
+- gameState *GameState: Completed session data
+
+Returns:
+- GameSession: Session record describing the game
+- bool: False if nobody scored, meaning there is nothing to record
+*/
+func buildGameSession(gameState *GameState) (GameSession, bool) {
+	if len(gameState.Scores) == 0 {
+		return GameSession{}, false
+	}
+
+	// Find winner (player with highest score in current session)
+	winner := ""
+	maxScore := -1
+	for player, score := range gameState.Scores {
+		if score > maxScore {
+			maxScore = score
+			winner = player
+		}
+	}
 
-		// Create comprehensive game session record
-		session := GameSession{
-			Difficulty:  gameState.Difficulty,
-			Winner:      winner,
-			Attempts:    gameState.Attempts,
-			Duration:    time.Since(gameState.StartTime),
-			PlayerCount: len(gameState.Players),
-			FinalScore:  maxScore,
-			Timestamp:   time.Now(),
+	// Only the winner's own turns count toward their personal efficiency
+	turns := 0
+	for _, record := range gameState.GuessLog {
+		if record.Player == winner {
+			turns++
 		}
+	}
 
-		*gameHistory = append(*gameHistory, session)
+	// Completion time is fixed when the game ends so repeated calls agree
+	endTime := gameState.EndTime
+	if endTime.IsZero() {
+		endTime = time.Now()
+	}
+
+	// Keep the unmultiplied score so records compare play, not handicaps
+	handicap := gameState.Handicaps[winner]
+	unhandicapped := 0
+	if handicap.scoreMultiplier() != 1 {
+		unhandicapped = calculateScore(gameState.Attempts, scoringDifficulty(gameState), endTime.Sub(gameState.StartTime))
 	}
+
+	return GameSession{
+		Difficulty:         gameState.Difficulty,
+		Winner:             winner,
+		Players:            append([]string(nil), gameState.Players...),
+		Attempts:           gameState.Attempts,
+		WinnerTurns:        turns,
+		Duration:           endTime.Sub(gameState.StartTime),
+		MaxRange:           gameState.MaxRange,
+		TimeLimit:          gameState.TimeLimit,
+		PlayerCount:        len(gameState.Players),
+		FinalScore:         maxScore,
+		UnhandicappedScore: unhandicapped,
+		Handicap:           handicap.String(),
+		Timestamp:          endTime,
+	}, true
 }
 
 /*
@@ -1022,11 +1403,12 @@ Analytics Features:
 Parameters:
 - leaderboard map[string]int: All-time player scores
 - gameHistory []GameSession: Complete session history
+- achievements map[string][]UnlockedAchievement: Unlocked achievements by player
 
 Data Analysis Components:
 1. Leaderboard Rankings - Sorted by total score
 2. Game Statistics - Aggregated metrics across sessions
-3. Performance Trends - Difficulty progression analysis
+3. Performance Trends - Sparkline, wins bar chart and attempts histograms
 4. Achievement Recognition - Notable accomplishments
 
 Statistical Calculations:
@@ -1034,7 +1416,8 @@ Statistical Calculations:
 - Win rate analysis and difficulty distribution
 - Player participation and engagement metrics
 */
-func displayFinalStatistics(leaderboard map[string]int, gameHistory []GameSession) {
+func displayFinalStatistics(leaderboard map[string]int, gameHistory []GameSession,
+	achievements map[string][]UnlockedAchievement) {
 	if len(leaderboard) == 0 && len(gameHistory) == 0 {
 		return
 	}
@@ -1063,28 +1446,32 @@ func displayFinalStatistics(leaderboard map[string]int, gameHistory []GameSessio
 
 		// Display ranked leaderboard with medals
 		for i, player := range sortedPlayers {
-			medal := ""
-			color := ColorWhite
-			switch i {
-			case 0:
-				medal = "🥇"
-				color = ColorYellow
-			case 1:
-				medal = "🥈"
-				color = ColorWhite
-			case 2:
-				medal = "🥉"
-				color = ColorYellow
-			default:
-				medal = fmt.Sprintf("%d.", i+1)
-				color = ColorCyan
-			}
-
+			medal, color := rankMedal(i)
 			fmt.Printf("  %s %s%s%s: %s%d points%s\n",
 				medal, color, player.Name, ColorReset, ColorGreen, player.Score, ColorReset)
 		}
 	}
 
+	// Display per-difficulty leaderboards for every difficulty played
+	now := time.Now()
+	for _, difficulty := range historyDifficulties() {
+		query := LeaderboardQuery{Difficulty: difficulty, Window: WindowAllTime, Mode: RankByTotal}
+		entries, err := queryLeaderboard(gameHistory, query, now)
+		if err != nil || len(entries) == 0 {
+			continue
+		}
+
+		fmt.Printf("\n%s %s Leaderboard:%s\n", ColorPurple, strings.Title(difficulty), ColorReset)
+		displayLeaderboard(entries, RankByTotal, 5)
+	}
+
+	// Display this week's form ranked by average points per game
+	weekly, err := queryLeaderboard(gameHistory, LeaderboardQuery{Window: WindowWeek, Mode: RankByAverage}, now)
+	if err == nil && len(weekly) > 0 {
+		fmt.Printf("\n%s This Week (Average per Game):%s\n", ColorPurple, ColorReset)
+		displayLeaderboard(weekly, RankByAverage, 5)
+	}
+
 	// Display Game History Analytics
 	if len(gameHistory) > 0 {
 		fmt.Printf("\n%s Game Session Analytics:%s\n", ColorPurple, ColorReset)
@@ -1116,22 +1503,18 @@ func displayFinalStatistics(leaderboard map[string]int, gameHistory []GameSessio
 				strings.Title(difficulty), ColorWhite, count, ColorReset, percentage)
 		}
 
-		// Display recent performance trend (last 5 games)
+		// Display recent performance trends as terminal charts
 		if totalGames >= 3 {
-			fmt.Printf("\n%s Recent Performance (Last %d Games):%s\n", ColorCyan, ColorReset,
-				min(5, totalGames))
-
-			startIdx := max(0, totalGames-5)
-			for i := startIdx; i < totalGames; i++ {
-				session := gameHistory[i]
-				fmt.Printf("  Game %d: %s%s%s won in %s%d attempts%s (%s%s%s)\n",
-					i+1, ColorGreen, session.Winner, ColorReset,
-					ColorYellow, session.Attempts, ColorReset,
-					ColorBlue, strings.Title(session.Difficulty), ColorReset)
-			}
+			displayPerformanceCharts(gameHistory)
 		}
 	}
 
+	// Display the global record holders for each difficulty
+	displayRecordBook(computeRecordBook(gameHistory))
+
+	// Display every player's unlocked achievements
+	displayAchievements(achievements)
+
 	printSeparator()
 }
 
@@ -1199,6 +1582,11 @@ func displayDifficultyHelp() {
 		ColorRed, HardMaxRange, ColorReset)
 	fmt.Printf("  • Scoring: 2x multiplier\n")
 	fmt.Printf("  • Strategy: Systematic approach essential\n")
+
+	fmt.Printf("%sAdaptive (1-%d to 1-%d):%s Fitted to the players' last %d games\n",
+		ColorPurple, AdaptiveMinRange, AdaptiveMaxRange, ColorReset, AdaptiveWindow)
+	fmt.Printf("  • Scoring: Multiplier of the closest fixed range\n")
+	fmt.Printf("  • Stronger players get a wider range and less time\n")
 	fmt.Println()
 }
 
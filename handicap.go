@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Handicap constants - Automatic handicaps scale with a player's rating gap
+// to the strongest player in the game; a full gap earns the maximum of each
+const (
+	HandicapMaxExtraTime  = 10 * time.Second // Extra time per turn for the weakest possible player
+	HandicapMaxHints      = 3                // Bonus hints for the weakest possible player
+	HandicapMaxMultiplier = 1.5              // Score multiplier for the weakest possible player
+	HandicapMinGap        = 0.1              // Smaller rating gaps get no handicap
+)
+
+// ErrInvalidHandicap is returned for handicap settings that cannot be parsed
+var ErrInvalidHandicap = errors.New("invalid handicap")
+
+/*
+Handicap evens out a game between players of different strength. The zero
+value is no handicap.
+*/
+type Handicap struct {
+	ExtraTime       time.Duration // Added to the time limit on the player's turns
+	BonusHints      int           // Turns starting with a free, public bisection of the feasible range
+	ScoreMultiplier float64       // Applied to the player's winning score; 0 means 1
+}
+
+// IsZero reports whether the handicap changes nothing
+func (h Handicap) IsZero() bool {
+	return h.ExtraTime == 0 && h.BonusHints == 0 && h.scoreMultiplier() == 1
+}
+
+// scoreMultiplier returns the factor for the player's winning score
+func (h Handicap) scoreMultiplier() float64 {
+	if h.ScoreMultiplier == 0 {
+		return 1
+	}
+	return h.ScoreMultiplier
+}
+
+/*
+String describes a handicap in the notation parseHandicap accepts, e.g.
+"+5s 2h x1.25".
+*/
+func (h Handicap) String() string {
+	var parts []string
+	if h.ExtraTime > 0 {
+		parts = append(parts, "+"+h.ExtraTime.String())
+	}
+	if h.BonusHints > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", h.BonusHints))
+	}
+	if multiplier := h.scoreMultiplier(); multiplier != 1 {
+		parts = append(parts, "x"+strconv.FormatFloat(multiplier, 'f', -1, 64))
+	}
+	return strings.Join(parts, " ")
+}
+
+/*
+describeHandicap spells a handicap out for the game header.
+*/
+func describeHandicap(h Handicap) string {
+	var parts []string
+	if h.ExtraTime > 0 {
+		parts = append(parts, fmt.Sprintf("+%s per turn", h.ExtraTime))
+	}
+	if h.BonusHints == 1 {
+		parts = append(parts, "1 bonus hint")
+	} else if h.BonusHints > 1 {
+		parts = append(parts, fmt.Sprintf("%d bonus hints", h.BonusHints))
+	}
+	if multiplier := h.scoreMultiplier(); multiplier != 1 {
+		parts = append(parts, fmt.Sprintf("x%.2f score", multiplier))
+	}
+	return strings.Join(parts, " • ")
+}
+
+/*
+parseHandicap reads a manual handicap: space-separated "+<duration>" for
+extra time, "<n>h" for bonus hints and "x<factor>" for a score multiplier,
+in any order and any combination. "+5s 2h x1.2" grants all three.
+
+Parameters:
+- text string: Handicap notation; empty means none
+
+Returns:
+- Handicap: Parsed handicap
+- error: ErrInvalidHandicap describing the first bad field
+*/
+func parseHandicap(text string) (Handicap, error) {
+	var h Handicap
+	for _, field := range strings.Fields(strings.ToLower(text)) {
+		switch {
+		case strings.HasPrefix(field, "+"):
+			extra, err := time.ParseDuration(field[1:])
+			if err != nil || extra < 0 || extra > time.Minute {
+				return Handicap{}, fmt.Errorf("%w: %q is not an extra time between 0s and 1m", ErrInvalidHandicap, field)
+			}
+			h.ExtraTime = extra
+		case strings.HasSuffix(field, "h"):
+			hints, err := strconv.Atoi(strings.TrimSuffix(field, "h"))
+			if err != nil || hints < 0 || hints > 10 {
+				return Handicap{}, fmt.Errorf("%w: %q is not a hint count between 0h and 10h", ErrInvalidHandicap, field)
+			}
+			h.BonusHints = hints
+		case strings.HasPrefix(field, "x"):
+			multiplier, err := strconv.ParseFloat(field[1:], 64)
+			if err != nil || multiplier < 0.5 || multiplier > 3 {
+				return Handicap{}, fmt.Errorf("%w: %q is not a score multiplier between x0.5 and x3", ErrInvalidHandicap, field)
+			}
+			h.ScoreMultiplier = multiplier
+		default:
+			return Handicap{}, fmt.Errorf("%w: %q (use +5s, 2h or x1.2)", ErrInvalidHandicap, field)
+		}
+	}
+	return h, nil
+}
+
+/*
+ratingHandicaps derives handicaps from the players' ratings - the skill
+estimate adaptive difficulty uses (see playerSkill).
+
+Each player's gap to the strongest player, as a share of the strongest
+rating, scales every kind of handicap; the strongest player and anyone
+within HandicapMinGap of them play without one.
+
+Parameters:
+- history []GameSession: Complete session history
+- players []string: Everyone in the game, computer players included
+
+Returns:
+- map[string]Handicap: Handicaps by player; players without one have no entry
+*/
+func ratingHandicaps(history []GameSession, players []string) map[string]Handicap {
+	ratings := make(map[string]float64, len(players))
+	best := 0.0
+	for _, player := range players {
+		ratings[player], _ = playerSkill(history, player)
+		best = math.Max(best, ratings[player])
+	}
+
+	handicaps := make(map[string]Handicap)
+	if best <= 0 {
+		return handicaps
+	}
+	for _, player := range players {
+		gap := (best - ratings[player]) / best
+		if gap < HandicapMinGap {
+			continue
+		}
+		handicaps[player] = Handicap{
+			ExtraTime:       (time.Duration(gap * float64(HandicapMaxExtraTime))).Round(time.Second),
+			BonusHints:      int(math.Round(gap * HandicapMaxHints)),
+			ScoreMultiplier: math.Round((1+gap*(HandicapMaxMultiplier-1))*20) / 20, // Nearest 0.05
+		}
+	}
+	return handicaps
+}
+
+/*
+selectHandicaps asks whether a multiplayer game should use handicaps and
+sets them up: derived from ratings, entered per player, or none.
+
+Parameters:
+- gameState *GameState: Session with its players registered
+
+Returns:
+- map[string]Handicap: Handicaps by player; players without one have no entry
+*/
+func selectHandicaps(gameState *GameState) map[string]Handicap {
+	handicaps := make(map[string]Handicap)
+	if len(gameState.Players) < 2 {
+		return handicaps
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Print("Handicaps? (Enter for none, 'auto' from ratings, 'manual' to set them): ")
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return handicaps
+		}
+
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "", "none", "n":
+			return handicaps
+		case "auto", "a":
+			handicaps = ratingHandicaps(gameState.GameHistory, gameState.Players)
+			if len(handicaps) == 0 {
+				printColoredMessage("Ratings are close - no handicaps needed.", ColorYellow)
+			}
+			return handicaps
+		case "manual", "m":
+			for _, player := range gameState.Players {
+				for {
+					fmt.Printf("Handicap for %s (e.g. '+5s 2h x1.2', Enter for none): ", player)
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return handicaps
+					}
+					h, err := parseHandicap(line)
+					if err != nil {
+						printColoredMessage(err.Error(), ColorRed)
+						continue
+					}
+					if !h.IsZero() {
+						handicaps[player] = h
+					}
+					break
+				}
+			}
+			return handicaps
+		default:
+			printColoredMessage("Please answer with Enter, 'auto' or 'manual'.", ColorRed)
+		}
+	}
+}
+
+/*
+bonusHint bisects the feasible range around the target - the information
+one perfect guess would have given - for a player with bonus hints left.
+The hot-seat game shares one screen, so the result becomes everyone's
+feasible range.
+
+Parameters:
+- low, high int: Feasible range
+- target int: The secret number
+
+Returns:
+- int, int: The half of the range holding the target
+*/
+func bonusHint(low, high, target int) (int, int) {
+	mid := (low + high) / 2
+	if target <= mid {
+		return low, mid
+	}
+	return mid + 1, high
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseHandicap(t *testing.T) {
+	tests := []struct {
+		text string
+		want Handicap
+		ok   bool
+	}{
+		{"", Handicap{}, true},
+		{"+5s", Handicap{ExtraTime: 5 * time.Second}, true},
+		{"2h", Handicap{BonusHints: 2}, true},
+		{"x1.2", Handicap{ScoreMultiplier: 1.2}, true},
+		{" X1.5  3H +1m ", Handicap{ExtraTime: time.Minute, BonusHints: 3, ScoreMultiplier: 1.5}, true},
+		{"+0s 0h", Handicap{}, true},
+		{"+2m", Handicap{}, false},
+		{"+-1s", Handicap{}, false},
+		{"11h", Handicap{}, false},
+		{"x0.4", Handicap{}, false},
+		{"x3.5", Handicap{}, false},
+		{"xfast", Handicap{}, false},
+		{"5s", Handicap{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := parseHandicap(tt.text)
+			if !tt.ok {
+				if !errors.Is(err, ErrInvalidHandicap) {
+					t.Errorf("got %+v, %v; want ErrInvalidHandicap", got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("got %+v, %v; want %+v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRatingHandicaps(t *testing.T) {
+	// Alice wins every game against Bob in six turns of her own
+	var history []GameSession
+	for day := 1; day <= 4; day++ {
+		history = append(history, GameSession{
+			Difficulty: "easy", Winner: "Alice", Players: []string{"Alice", "Bob"},
+			Attempts: 12, MaxRange: EasyMaxRange, PlayerCount: 2, FinalScore: 500,
+			Timestamp: time.Date(2026, 10, day, 12, 0, 0, 0, time.Local),
+		})
+	}
+	strongest := Handicap{ExtraTime: HandicapMaxExtraTime, BonusHints: HandicapMaxHints, ScoreMultiplier: HandicapMaxMultiplier}
+
+	tests := []struct {
+		name    string
+		history []GameSession
+		players []string
+		want    map[string]Handicap
+	}{
+		{"no history means equal ratings", nil, []string{"Alice", "Bob"}, map[string]Handicap{}},
+		{"a player who never wins gets the full handicap", history, []string{"Alice", "Bob"},
+			map[string]Handicap{"Bob": strongest}},
+		{"players without ratings against the strongest", history, []string{"Alice", "Carol"},
+			map[string]Handicap{"Carol": {ExtraTime: 3 * time.Second, BonusHints: 1, ScoreMultiplier: 1.15}}},
+		{"equal ratings", history, []string{"Carol", "Dave"}, map[string]Handicap{}},
+		{"only losers", history, []string{"Bob"}, map[string]Handicap{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ratingHandicaps(tt.history, tt.players)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+			for player, want := range tt.want {
+				if got[player] != want {
+					t.Errorf("%s: got %+v, want %+v", player, got[player], want)
+				}
+			}
+		})
+	}
+}
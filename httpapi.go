@@ -0,0 +1,641 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gaming/my-guessing-game/protocol"
+)
+
+// HTTP API constants
+const (
+	DefaultHTTPAddr = ":8080" // Default HTTP listen address
+	MaxRequestBody  = 4096    // Largest accepted request body in bytes
+	HistoryLimit    = 50      // Default number of sessions returned by /api/history
+)
+
+/*
+apiServer exposes the lobby's games, the leaderboard and the game history as
+a JSON API.
+
+Games are lobby rooms (the game ID is the invite code), so HTTP players
+follow exactly the same rules, turn order and timeouts as TCP players and as
+runGameSession, and can share a room with them. Each joined player receives
+a session token that must accompany their requests as a bearer token.
+
+Routes:
+
+	GET  /api/games                  List games
+	POST /api/games                  Create a game {difficulty, players, time_limit_ms, spectators_see_target}
+	GET  /api/games/{id}             Poll a game's state
+	GET  /api/games/{id}/events      Stream the game's events (Server-Sent Events); ?spectate=1 for spectators
+	POST /api/games/{id}/settings    Change {spectators_see_target} (host only, bearer token)
+	POST /api/games/{id}/players     Join {name} -> {token, ...}
+	POST /api/games/{id}/start       Start early (host only, bearer token)
+	POST /api/games/{id}/guesses     Guess {guess, turn} with "Authorization: Bearer <token>"
+	POST /api/games/{id}/chat        Chat {text} or {emote} (bearer token)
+	POST /api/games/{id}/leave       Give up the seat (bearer token)
+	GET  /api/leaderboard            ?difficulty=&window=&mode=&limit=
+	GET  /api/history                ?player=&from=&to=&limit=
+	GET  /                           Embedded browser UI
+*/
+type apiServer struct {
+	lobby    *Lobby
+	mux      *http.ServeMux
+	requests *requestLimiter // Rate limit for state-changing requests
+}
+
+/*
+apiError is the body of every non-2xx response.
+
+Code reuses the stable protocol error codes so HTTP and TCP clients can
+share error handling.
+*/
+type apiError struct {
+	Code  string `json:"code"`
+	Error string `json:"error"`
+}
+
+/*
+apiGameView is a game as returned by the API.
+*/
+type apiGameView struct {
+	ID string `json:"id"`
+	MatchSnapshot
+	Spectators          int  `json:"spectators"`
+	SpectatorsSeeTarget bool `json:"spectators_see_target"`
+}
+
+/*
+newAPIServer creates the API handler.
+
+Parameters:
+- lobby *Lobby: Rooms to expose; its save file backs leaderboard and history
+
+Returns:
+- *apiServer: Server ready to be used as an http.Handler
+*/
+func newAPIServer(lobby *Lobby) *apiServer {
+	s := &apiServer{
+		lobby:    lobby,
+		mux:      http.NewServeMux(),
+		requests: newRequestLimiter(),
+	}
+
+	s.mux.HandleFunc("GET /api/games", s.handleListGames)
+	s.mux.HandleFunc("POST /api/games", s.handleCreateGame)
+	s.mux.HandleFunc("GET /api/games/{id}", s.handleGetGame)
+	s.mux.HandleFunc("GET /api/games/{id}/events", s.handleGameEvents)
+	s.mux.HandleFunc("POST /api/games/{id}/players", s.handleJoinGame)
+	s.mux.HandleFunc("POST /api/games/{id}/start", s.handleStartGame)
+	s.mux.HandleFunc("POST /api/games/{id}/settings", s.handleGameSettings)
+	s.mux.HandleFunc("POST /api/games/{id}/guesses", s.handleGuess)
+	s.mux.HandleFunc("POST /api/games/{id}/chat", s.handleChat)
+	s.mux.HandleFunc("POST /api/games/{id}/leave", s.handleLeaveGame)
+	s.mux.HandleFunc("GET /api/leaderboard", s.handleLeaderboard)
+	s.mux.HandleFunc("GET /api/history", s.handleHistory)
+	s.mux.Handle("GET /", webHandler())
+	return s
+}
+
+// ServeHTTP implements http.Handler, rate-limiting requests that change state
+func (s *apiServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead && !s.requests.Allow(r) {
+		writeAPIError(w, http.StatusTooManyRequests, protocol.CodeRateLimited, "too many requests; slow down")
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+/*
+newHTTPServer wraps the API for a lobby in an http.Server.
+*/
+func newHTTPServer(addr string, lobby *Lobby) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           newAPIServer(lobby),
+		ReadHeaderTimeout: JoinTimeout,
+	}
+}
+
+/*
+runHTTPCommand serves the JSON API and the browser UI.
+
+Usage:
+
+	guessing-game http [-addr :8080] [-admin 127.0.0.1:7780] [-metrics :9100]
+
+Use "serve -http" to share rooms with TCP players as well. With -admin, the
+operator can manage rooms through the admin API; with -metrics, Prometheus
+can scrape /metrics.
+
+Parameters:
+- args []string: Subcommand arguments
+
+Returns:
+- int: Process exit code
+*/
+func runHTTPCommand(args []string) int {
+	flags := flag.NewFlagSet("http", flag.ContinueOnError)
+	addr := flags.String("addr", DefaultHTTPAddr, "HTTP address to listen on")
+	adminAddr := flags.String("admin", "", "serve the admin API on this address, e.g. "+DefaultAdminAddr)
+	adminToken := flags.String("admin-token", os.Getenv(AdminTokenEnv), "admin API token (default $"+AdminTokenEnv+", or generated)")
+	metricsAddr := flags.String("metrics", "", "serve Prometheus metrics at /metrics on this address")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	// Fail early on an unreadable save file rather than on the first request
+	if loadSaveDataOrReport() == nil {
+		return 1
+	}
+
+	defaults := RoomSettings{Difficulty: "medium", Capacity: 2, TimeLimit: DefaultTimeLimit}
+	lobby := newLobby(dataFilePath(), defaults, DefaultReconnectGrace)
+	server := newHTTPServer(*addr, lobby)
+
+	printColoredHeader("🌐 HTTP Game Server")
+	fmt.Printf("%sListening on:%s http://%s/\n", ColorBlue, ColorReset, *addr)
+	stopAdmin, err := startAdminServer(*adminAddr, *adminToken, lobby)
+	if err != nil {
+		printColoredMessage(fmt.Sprintf("Could not listen on %s: %v", *adminAddr, err), ColorRed)
+		return 1
+	}
+	defer stopAdmin()
+	stopMetrics, err := startMetricsServer(*metricsAddr, lobby)
+	if err != nil {
+		printColoredMessage(fmt.Sprintf("Could not listen on %s: %v", *metricsAddr, err), ColorRed)
+		return 1
+	}
+	defer stopMetrics()
+	printSeparator()
+
+	if err := server.ListenAndServe(); err != nil {
+		printColoredMessage(fmt.Sprintf("HTTP server stopped: %v", err), ColorRed)
+		return 1
+	}
+	return 0
+}
+
+/*
+handleListGames lists every game in the lobby, joinable ones first.
+*/
+func (s *apiServer) handleListGames(w http.ResponseWriter, r *http.Request) {
+	rooms := s.lobby.Rooms()
+	views := make([]apiGameView, 0, len(rooms))
+	for _, room := range rooms {
+		views = append(views, roomView(room))
+	}
+
+	sort.SliceStable(views, func(i, j int) bool {
+		return !views[i].Started && views[j].Started
+	})
+	writeJSON(w, http.StatusOK, views)
+}
+
+/*
+handleCreateGame creates a game waiting for players.
+
+Request Body (every field optional, defaulting to the lobby's settings):
+- difficulty: easy, medium or hard
+- players: Number of seats, 1 to MaxPlayers
+- time_limit_ms: Time allowed per guess
+- spectators_see_target: Show the target to spectator streams
+*/
+func (s *apiServer) handleCreateGame(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Difficulty          string `json:"difficulty"`
+		Players             int    `json:"players"`
+		TimeLimitMS         int64  `json:"time_limit_ms"`
+		SpectatorsSeeTarget bool   `json:"spectators_see_target"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	room, err := s.lobby.CreateRoom(RoomSettings{
+		Difficulty: req.Difficulty,
+		Capacity:   req.Players,
+		TimeLimit:  time.Duration(req.TimeLimitMS) * time.Millisecond,
+
+		SpectatorsSeeTarget: req.SpectatorsSeeTarget,
+	})
+	if err != nil {
+		writeMatchError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, roomView(room))
+}
+
+/*
+handleGetGame returns a game's current state for polling clients.
+*/
+func (s *apiServer) handleGetGame(w http.ResponseWriter, r *http.Request) {
+	room, ok := s.lookupRoom(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, roomView(room))
+}
+
+/*
+handleJoinGame seats a player and issues their session token.
+
+The game starts automatically once every seat is taken.
+*/
+func (s *apiServer) handleJoinGame(w http.ResponseWriter, r *http.Request) {
+	room, ok := s.lookupRoom(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Name string `json:"name"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	token, err := room.Join(req.Name)
+	if err != nil {
+		writeMatchError(w, err)
+		return
+	}
+	room.PlayingFrom(strings.TrimSpace(req.Name), r.RemoteAddr)
+
+	writeJSON(w, http.StatusCreated, struct {
+		Token  string      `json:"token"`
+		Player string      `json:"player"`
+		Game   apiGameView `json:"game"`
+	}{token, strings.TrimSpace(req.Name), roomView(room)})
+}
+
+/*
+handleStartGame starts the game early on the host's request.
+*/
+func (s *apiServer) handleStartGame(w http.ResponseWriter, r *http.Request) {
+	room, player, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	if err := room.Match.StartBy(player); err != nil {
+		writeMatchError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, roomView(room))
+}
+
+/*
+handleGameSettings changes room settings on the host's request.
+
+Request Body:
+- spectators_see_target: Whether spectator streams show the target
+*/
+func (s *apiServer) handleGameSettings(w http.ResponseWriter, r *http.Request) {
+	room, player, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		SpectatorsSeeTarget bool `json:"spectators_see_target"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if err := room.SetRevealTarget(player, req.SpectatorsSeeTarget); err != nil {
+		writeMatchError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, roomView(room))
+}
+
+/*
+handleGuess submits a guess for the player identified by the bearer token.
+
+Request Body:
+  - guess: The guess as typed; the server validates it
+  - turn: Turn number from the game's state; naming it guarantees a retried
+    or duplicated request cannot count twice. Guesses without one are
+    rejected as malformed
+*/
+func (s *apiServer) handleGuess(w http.ResponseWriter, r *http.Request) {
+	room, player, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Guess string `json:"guess"`
+		Turn  int    `json:"turn"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	result, err := room.Match.SubmitGuess(player, req.Guess, req.Turn)
+	switch {
+	case errors.Is(err, ErrNotYourTurn):
+		reportSuspicious("["+room.Code+"] ", player, "guess out of turn over HTTP")
+	case errors.Is(err, ErrStaleTurn):
+		reportSuspicious("["+room.Code+"] ", player, "second guess in one turn over HTTP")
+	}
+	if err != nil {
+		writeMatchError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		Result GuessView   `json:"result"`
+		Game   apiGameView `json:"game"`
+	}{
+		GuessView{
+			Player:  player,
+			Value:   result.Value,
+			Valid:   result.Valid,
+			Correct: result.Correct,
+			Hint:    result.Hint,
+		},
+		roomView(room),
+	})
+}
+
+/*
+handleChat relays a chat message or emote from the player identified by the
+bearer token. Chat arrives on the events stream like everything else.
+
+Request Body:
+- text: Free-form message, or
+- emote: One of the quick emote names
+*/
+func (s *apiServer) handleChat(w http.ResponseWriter, r *http.Request) {
+	room, player, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Text  string `json:"text"`
+		Emote string `json:"emote"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if err := room.Chat(player, req.Text, req.Emote); err != nil {
+		writeMatchError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+/*
+handleLeaveGame ends the session of the player identified by the bearer
+token. The room closes once everyone has left.
+*/
+func (s *apiServer) handleLeaveGame(w http.ResponseWriter, r *http.Request) {
+	room, player, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	room.Leave(player)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+/*
+handleLeaderboard serves a filtered leaderboard from the save file.
+
+Query parameters mirror the leaderboard subcommand: difficulty, window,
+mode and limit (0 or absent means no limit).
+*/
+func (s *apiServer) handleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	limit, ok := parseLimit(w, params.Get("limit"), 0)
+	if !ok {
+		return
+	}
+
+	saveData, err := loadSaveData(s.lobby.dataPath)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, protocol.CodeInternal, err.Error())
+		return
+	}
+
+	query := LeaderboardQuery{
+		Difficulty: params.Get("difficulty"),
+		Window:     params.Get("window"),
+		Mode:       params.Get("mode"),
+	}
+	entries, err := queryLeaderboard(saveData.GameHistory, query, time.Now())
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, protocol.CodeMalformed, err.Error())
+		return
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	if entries == nil {
+		entries = []LeaderboardEntry{}
+	}
+
+	writeJSON(w, http.StatusOK, entries)
+}
+
+/*
+handleHistory serves recorded game sessions, newest first.
+
+Query parameters mirror the export subcommand's filters: player, from and
+to (YYYY-MM-DD), plus limit (default HistoryLimit, 0 for everything).
+*/
+func (s *apiServer) handleHistory(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	limit, ok := parseLimit(w, params.Get("limit"), HistoryLimit)
+	if !ok {
+		return
+	}
+
+	filter := ExportFilter{Player: strings.TrimSpace(params.Get("player"))}
+	for _, bound := range []struct {
+		name   string
+		target *time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		value := params.Get(bound.name)
+		if value == "" {
+			continue
+		}
+		date, err := parseExportDate(value)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, protocol.CodeMalformed,
+				fmt.Sprintf("invalid %s date: %v", bound.name, err))
+			return
+		}
+		*bound.target = date
+	}
+	if !filter.To.IsZero() {
+		filter.To = filter.To.AddDate(0, 0, 1) // Make the end date inclusive, as the export command does
+	}
+
+	saveData, err := loadSaveData(s.lobby.dataPath)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, protocol.CodeInternal, err.Error())
+		return
+	}
+
+	sessions := []GameSession{}
+	for i := len(saveData.GameHistory) - 1; i >= 0 && (limit == 0 || len(sessions) < limit); i-- {
+		if filter.matches(saveData.GameHistory[i]) {
+			sessions = append(sessions, saveData.GameHistory[i])
+		}
+	}
+
+	writeJSON(w, http.StatusOK, sessions)
+}
+
+/*
+lookupRoom resolves the {id} path value, writing a 404 if it is unknown.
+*/
+func (s *apiServer) lookupRoom(w http.ResponseWriter, r *http.Request) (*Room, bool) {
+	room, err := s.lobby.Room(r.PathValue("id"))
+	if err != nil {
+		writeMatchError(w, err)
+		return nil, false
+	}
+	return room, true
+}
+
+/*
+authenticate resolves the room and the player owning the request's bearer
+token, writing an error response on failure.
+*/
+func (s *apiServer) authenticate(w http.ResponseWriter, r *http.Request) (*Room, string, bool) {
+	room, ok := s.lookupRoom(w, r)
+	if !ok {
+		return nil, "", false
+	}
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	player, err := room.Player(token)
+	if err != nil {
+		if errors.Is(err, ErrUnknownSession) {
+			reportSuspicious("["+room.Code+"] ", r.RemoteAddr, "request with an unknown session token")
+		}
+		writeMatchError(w, err)
+		return nil, "", false
+	}
+	room.PlayingFrom(player, r.RemoteAddr)
+	return room, player, true
+}
+
+/*
+roomView renders a room for API responses.
+*/
+func roomView(room *Room) apiGameView {
+	info := room.Info()
+	return apiGameView{
+		ID:                  room.Code,
+		MatchSnapshot:       room.Match.Snapshot(),
+		Spectators:          info.Spectators,
+		SpectatorsSeeTarget: info.SpectatorsSeeTarget,
+	}
+}
+
+// matchErrorStatus maps match and lobby errors to HTTP status codes
+var matchErrorStatus = map[error]int{
+	ErrInvalidName:   http.StatusBadRequest,
+	ErrNameTaken:     http.StatusConflict,
+	ErrMatchFull:     http.StatusConflict,
+	ErrMatchStarted:  http.StatusConflict,
+	ErrMatchNotReady: http.StatusConflict,
+	ErrMatchOver:     http.StatusGone,
+	ErrNotYourTurn:   http.StatusConflict,
+	ErrNotHost:       http.StatusForbidden,
+	ErrMatchAborted:  http.StatusGone,
+	ErrStaleTurn:     http.StatusConflict,
+	ErrMissingTurn:   http.StatusBadRequest,
+
+	ErrRoomNotFound:    http.StatusNotFound,
+	ErrNoOpenRoom:      http.StatusNotFound,
+	ErrInvalidSettings: http.StatusBadRequest,
+	ErrUnknownSession:  http.StatusUnauthorized,
+	ErrNoSuchPlayer:    http.StatusNotFound,
+	ErrKicked:          http.StatusForbidden,
+	ErrSeatedSpectator: http.StatusForbidden,
+
+	ErrRateLimited: http.StatusTooManyRequests,
+	ErrInvalidChat: http.StatusBadRequest,
+}
+
+/*
+writeMatchError reports a match error with the same code a TCP client
+would receive.
+*/
+func writeMatchError(w http.ResponseWriter, err error) {
+	msg := errorMessage(err)
+	status := http.StatusInternalServerError
+	for matchErr, code := range matchErrorStatus {
+		if errors.Is(err, matchErr) {
+			status = code
+			break
+		}
+	}
+	writeAPIError(w, status, msg.Code, msg.Message)
+}
+
+/*
+writeAPIError writes an apiError body.
+*/
+func writeAPIError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, apiError{Code: code, Error: message})
+}
+
+/*
+writeJSON writes v as the JSON response body.
+*/
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+/*
+decodeJSON reads a size-limited JSON request body into v, writing a 400 on
+failure. An empty body leaves v at its zero value.
+
+Returns:
+- bool: True if the handler should continue
+*/
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if r.ContentLength == 0 {
+		return true
+	}
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxRequestBody))
+	if err := decoder.Decode(v); err != nil {
+		writeAPIError(w, http.StatusBadRequest, protocol.CodeMalformed, "invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+/*
+parseLimit parses an optional non-negative limit query parameter, writing a
+400 on failure.
+*/
+func parseLimit(w http.ResponseWriter, value string, fallback int) (int, bool) {
+	if value == "" {
+		return fallback, true
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		writeAPIError(w, http.StatusBadRequest, protocol.CodeMalformed, "limit must be a non-negative integer")
+		return 0, false
+	}
+	return limit, true
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gaming/my-guessing-game/protocol"
+)
+
+// testHistory is the save file every API test server starts from
+var testHistory = []GameSession{
+	{Difficulty: "easy", Winner: "Alice", Players: []string{"Alice", "Bob"}, Attempts: 6, WinnerTurns: 3,
+		Duration: 20 * time.Second, PlayerCount: 2, FinalScore: 700,
+		Timestamp: time.Date(2026, 10, 1, 12, 0, 0, 0, time.Local)},
+	{Difficulty: "hard", Winner: "Bob", Players: []string{"Bob"}, Attempts: 7, WinnerTurns: 7,
+		Duration: 40 * time.Second, PlayerCount: 1, FinalScore: 1200,
+		Timestamp: time.Date(2026, 10, 2, 12, 0, 0, 0, time.Local)},
+	{Difficulty: "easy", Winner: "Alice", Players: []string{"Alice"}, Attempts: 4, WinnerTurns: 4,
+		Duration: 15 * time.Second, PlayerCount: 1, FinalScore: 800,
+		Timestamp: time.Date(2026, 10, 3, 12, 0, 0, 0, time.Local)},
+}
+
+/*
+newTestAPI creates an API server backed by a fresh lobby whose save file
+holds testHistory.
+*/
+func newTestAPI(t *testing.T) *apiServer {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "save.json")
+	data := newSaveData()
+	data.GameHistory = append([]GameSession(nil), testHistory...)
+	for _, session := range testHistory {
+		data.Leaderboard[session.Winner] += session.FinalScore
+	}
+	if err := saveSaveData(path, data); err != nil {
+		t.Fatalf("writing save file: %v", err)
+	}
+
+	defaults := RoomSettings{Difficulty: "medium", Capacity: 2, TimeLimit: time.Minute}
+	return newAPIServer(newLobby(path, defaults, DefaultReconnectGrace))
+}
+
+/*
+doRequest sends a request to the API and decodes the JSON response into out,
+which may be nil.
+
+Returns:
+- int: Response status code
+*/
+func doRequest(t *testing.T, api *apiServer, method, target, token, body string, out any) int {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	api.ServeHTTP(rec, req)
+
+	if out != nil && rec.Body.Len() > 0 {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: decoding %q: %v", method, target, rec.Body.String(), err)
+		}
+	}
+	return rec.Code
+}
+
+// expectError checks an error response's status and protocol code
+func expectError(t *testing.T, api *apiServer, method, target, token, body string, status int, code string) {
+	t.Helper()
+	var apiErr apiError
+	if got := doRequest(t, api, method, target, token, body, &apiErr); got != status || apiErr.Code != code {
+		t.Errorf("%s %s %s: got %d %q (%s), want %d %q", method, target, body, got, apiErr.Code, apiErr.Error, status, code)
+	}
+}
+
+// joinResponse is the body of a successful join
+type joinResponse struct {
+	Token  string      `json:"token"`
+	Player string      `json:"player"`
+	Game   apiGameView `json:"game"`
+}
+
+/*
+startTestGame creates an easy two-player game and seats Alice and Bob,
+which starts it.
+
+Returns:
+- string: Game ID
+- map[string]string: Session tokens by player
+*/
+func startTestGame(t *testing.T, api *apiServer) (string, map[string]string) {
+	t.Helper()
+	var game apiGameView
+	if status := doRequest(t, api, "POST", "/api/games", "", `{"difficulty":"easy","players":2}`, &game); status != http.StatusCreated {
+		t.Fatalf("create: got %d, want %d", status, http.StatusCreated)
+	}
+	if game.ID == "" || game.Difficulty != "easy" || game.Capacity != 2 || game.Started {
+		t.Fatalf("create: unexpected game %+v", game)
+	}
+
+	tokens := make(map[string]string)
+	for _, name := range []string{"Alice", "Bob"} {
+		var joined joinResponse
+		if status := doRequest(t, api, "POST", "/api/games/"+game.ID+"/players", "", fmt.Sprintf(`{"name":%q}`, name), &joined); status != http.StatusCreated {
+			t.Fatalf("join %s: got %d, want %d", name, status, http.StatusCreated)
+		}
+		if joined.Token == "" || joined.Player != name {
+			t.Fatalf("join %s: unexpected response %+v", name, joined)
+		}
+		tokens[name] = joined.Token
+	}
+	return game.ID, tokens
+}
+
+func TestCreateJoinAndPlay(t *testing.T) {
+	api := newTestAPI(t)
+	id, tokens := startTestGame(t, api)
+
+	var game apiGameView
+	if status := doRequest(t, api, "GET", "/api/games/"+id, "", "", &game); status != http.StatusOK {
+		t.Fatalf("state: got %d, want %d", status, http.StatusOK)
+	}
+	if !game.Started || game.CurrentPlayer != "Alice" || game.Turn != 1 {
+		t.Fatalf("state after both joined: started=%v current=%q turn=%d", game.Started, game.CurrentPlayer, game.Turn)
+	}
+	if game.Target != 0 {
+		t.Fatalf("state leaked the target %d", game.Target)
+	}
+
+	room, _ := api.lobby.Room(id)
+	target := room.Match.Target()
+	miss := target%EasyMaxRange + 1 // Any valid number but the target
+
+	var result struct {
+		Result GuessView   `json:"result"`
+		Game   apiGameView `json:"game"`
+	}
+	body := fmt.Sprintf(`{"guess":"%d","turn":1}`, miss)
+	if status := doRequest(t, api, "POST", "/api/games/"+id+"/guesses", tokens["Alice"], body, &result); status != http.StatusOK {
+		t.Fatalf("guess: got %d, want %d", status, http.StatusOK)
+	}
+	if result.Result.Correct || !result.Result.Valid || result.Game.CurrentPlayer != "Bob" || result.Game.Turn != 2 {
+		t.Fatalf("after a miss: result %+v, current %q, turn %d", result.Result, result.Game.CurrentPlayer, result.Game.Turn)
+	}
+
+	body = fmt.Sprintf(`{"guess":"%d","turn":2}`, target)
+	if status := doRequest(t, api, "POST", "/api/games/"+id+"/guesses", tokens["Bob"], body, &result); status != http.StatusOK {
+		t.Fatalf("winning guess: got %d, want %d", status, http.StatusOK)
+	}
+	if !result.Result.Correct || !result.Game.Finished || result.Game.Winner != "Bob" || result.Game.Target != target {
+		t.Fatalf("after the winning guess: result %+v, finished=%v winner=%q target=%d",
+			result.Result, result.Game.Finished, result.Game.Winner, result.Game.Target)
+	}
+
+	// The result is recorded in the background; it becomes the newest session
+	for deadline := time.Now().Add(2 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		var sessions []GameSession
+		doRequest(t, api, "GET", "/api/history?limit=1", "", "", &sessions)
+		if len(sessions) == 1 && sessions[0].Winner == "Bob" && sessions[0].WinnerTurns == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("finished game never reached the history: newest is %+v", sessions)
+		}
+	}
+}
+
+func TestGuessErrors(t *testing.T) {
+	api := newTestAPI(t)
+	id, tokens := startTestGame(t, api)
+	guesses := "/api/games/" + id + "/guesses"
+
+	tests := []struct {
+		name   string
+		token  string
+		body   string
+		status int
+		code   string
+	}{
+		{"malformed body", tokens["Alice"], `{"guess":`, http.StatusBadRequest, protocol.CodeMalformed},
+		{"wrong field type", tokens["Alice"], `{"guess":"10","turn":"one"}`, http.StatusBadRequest, protocol.CodeMalformed},
+		{"missing turn", tokens["Alice"], `{"guess":"10"}`, http.StatusBadRequest, protocol.CodeMalformed},
+		{"out of turn", tokens["Bob"], `{"guess":"10","turn":1}`, http.StatusConflict, protocol.CodeNotYourTurn},
+		{"stale turn", tokens["Alice"], `{"guess":"10","turn":5}`, http.StatusConflict, protocol.CodeStaleTurn},
+		{"no token", "", `{"guess":"10","turn":1}`, http.StatusUnauthorized, protocol.CodeInvalidSession},
+		{"unknown token", "not-a-token", `{"guess":"10","turn":1}`, http.StatusUnauthorized, protocol.CodeInvalidSession},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectError(t, api, "POST", guesses, tt.token, tt.body, tt.status, tt.code)
+		})
+	}
+
+	// None of the refused guesses may have used up Alice's turn
+	var game apiGameView
+	doRequest(t, api, "GET", "/api/games/"+id, "", "", &game)
+	if game.Attempts != 0 || game.CurrentPlayer != "Alice" {
+		t.Fatalf("refused guesses changed the game: attempts=%d current=%q", game.Attempts, game.CurrentPlayer)
+	}
+}
+
+func TestGameErrors(t *testing.T) {
+	api := newTestAPI(t)
+	id, _ := startTestGame(t, api)
+
+	expectError(t, api, "POST", "/api/games", "", `{"difficulty":"impossible"}`, http.StatusBadRequest, protocol.CodeMalformed)
+	expectError(t, api, "POST", "/api/games", "", `not json`, http.StatusBadRequest, protocol.CodeMalformed)
+	expectError(t, api, "GET", "/api/games/NOPE42", "", "", http.StatusNotFound, protocol.CodeNotFound)
+	expectError(t, api, "POST", "/api/games/"+id+"/players", "", `{"name":"Carol"}`, http.StatusConflict, protocol.CodeGameStarted)
+	expectError(t, api, "POST", "/api/games/"+id+"/players", "", `{"name":`, http.StatusBadRequest, protocol.CodeMalformed)
+}
+
+func TestLeaderboard(t *testing.T) {
+	api := newTestAPI(t)
+
+	var entries []LeaderboardEntry
+	if status := doRequest(t, api, "GET", "/api/leaderboard", "", "", &entries); status != http.StatusOK {
+		t.Fatalf("leaderboard: got %d, want %d", status, http.StatusOK)
+	}
+	if len(entries) != 2 || entries[0].Name != "Alice" || entries[0].TotalScore != 1500 || entries[0].Wins != 2 {
+		t.Fatalf("leaderboard: got %+v, want Alice first with 1500 points from 2 wins", entries)
+	}
+
+	if status := doRequest(t, api, "GET", "/api/leaderboard?difficulty=hard&limit=1", "", "", &entries); status != http.StatusOK {
+		t.Fatalf("hard leaderboard: got %d, want %d", status, http.StatusOK)
+	}
+	if len(entries) != 1 || entries[0].Name != "Bob" {
+		t.Fatalf("hard leaderboard: got %+v, want Bob only", entries)
+	}
+
+	expectError(t, api, "GET", "/api/leaderboard?difficulty=impossible", "", "", http.StatusBadRequest, protocol.CodeMalformed)
+	expectError(t, api, "GET", "/api/leaderboard?mode=sideways", "", "", http.StatusBadRequest, protocol.CodeMalformed)
+	expectError(t, api, "GET", "/api/leaderboard?limit=-1", "", "", http.StatusBadRequest, protocol.CodeMalformed)
+}
+
+func TestHistory(t *testing.T) {
+	api := newTestAPI(t)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string // Winners, newest first
+	}{
+		{"everything", "", []string{"Alice", "Bob", "Alice"}},
+		{"limit", "?limit=1", []string{"Alice"}},
+		{"player", "?player=Bob", []string{"Bob", "Alice"}},
+		{"from", "?from=2026-10-02", []string{"Alice", "Bob"}},
+		{"to is inclusive", "?to=2026-10-02", []string{"Bob", "Alice"}},
+		{"single day", "?from=2026-10-02&to=2026-10-02", []string{"Bob"}},
+		{"no match", "?from=2027-01-01", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sessions []GameSession
+			if status := doRequest(t, api, "GET", "/api/history"+tt.query, "", "", &sessions); status != http.StatusOK {
+				t.Fatalf("got %d, want %d", status, http.StatusOK)
+			}
+			winners := []string{}
+			for _, session := range sessions {
+				winners = append(winners, session.Winner)
+			}
+			if strings.Join(winners, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("got winners %v, want %v", winners, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistoryBadDates(t *testing.T) {
+	api := newTestAPI(t)
+	for _, query := range []string{
+		"?from=yesterday",
+		"?to=2026-13-01",
+		"?from=2026-10-01&to=10/03/2026",
+		"?limit=many",
+	} {
+		expectError(t, api, "GET", "/api/history"+query, "", "", http.StatusBadRequest, protocol.CodeMalformed)
+	}
+}
@@ -0,0 +1,423 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"gaming/my-guessing-game/client"
+	"gaming/my-guessing-game/protocol"
+)
+
+// Reconnection settings - Together they stay well inside the server's
+// default grace period
+const (
+	ReconnectAttempts = 5
+	ReconnectDelay    = 2 * time.Second
+)
+
+/*
+runJoinCommand plays a networked game from the terminal.
+
+Usage:
+
+	guessing-game join [-addr localhost:7777] [-name Alice] [-room CODE]
+	guessing-game join -create [-difficulty hard] [-players 3] [-time-limit 15s]
+	guessing-game join -room CODE -token TOKEN
+	guessing-game join -watch [-room CODE]
+
+Without -room or -create the open rooms are listed and the player picks an
+invite code, creates a room, or joins the first open one. Typed lines are
+sent to the server as guesses; the server decides whose turn it is. The
+host may type "start" to begin before every seat is taken, and "reveal on"
+or "reveal off" to decide whether spectators see the target. Chat commands
+("say", "emote", "mute"; see displayNetworkHelp) are never sent as guesses.
+If the connection drops, the client resumes its seat automatically with its
+session token. With -watch the room is followed read-only (see watchGame).
+
+Parameters:
+- args []string: Subcommand arguments
+
+Returns:
+- int: Process exit code
+*/
+func runJoinCommand(args []string) int {
+	flags := flag.NewFlagSet("join", flag.ContinueOnError)
+	addr := flags.String("addr", "localhost"+DefaultServeAddr, "server address (host:port)")
+	name := flags.String("name", "", "player name (prompted for if omitted)")
+	room := flags.String("room", "", "invite code of the room to join")
+	create := flags.Bool("create", false, "create a new room and host it")
+	difficulty := flags.String("difficulty", "", "difficulty for a new room (server default if empty)")
+	players := flags.Int("players", 0, "seats in a new room (server default if 0)")
+	timeLimit := flags.Duration("time-limit", 0, "time per guess in a new room (server default if 0)")
+	token := flags.String("token", "", "session token to resume a seat in -room")
+	watch := flags.Bool("watch", false, "spectate -room without playing")
+	reveal := flags.Bool("spectators-see-target", false, "let spectators of a new room see the target")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	input := bufio.NewReader(os.Stdin)
+	if *watch {
+		if *room == "" {
+			fmt.Print("Enter the invite code of the room to watch: ")
+			line, _ := input.ReadString('\n')
+			*room = strings.TrimSpace(line)
+		}
+		return watchGame(*addr, *room)
+	}
+
+	if strings.TrimSpace(*name) == "" && *token == "" {
+		fmt.Print("Enter your player name: ")
+		line, _ := input.ReadString('\n')
+		*name = strings.TrimSpace(line)
+	}
+
+	// Let the player choose a room when the flags do not say which
+	if !*create && *room == "" && *token == "" {
+		rooms, err := client.ListRooms(*addr)
+		if err != nil {
+			printColoredMessage(fmt.Sprintf("Could not reach %s: %v", *addr, err), ColorRed)
+			return 1
+		}
+		displayRoomList(rooms)
+		fmt.Print("Enter an invite code, 'new' to create a room, or press Enter for any open room: ")
+		line, _ := input.ReadString('\n')
+		choice := strings.TrimSpace(line)
+		if strings.EqualFold(choice, "new") {
+			*create = true
+		} else {
+			*room = choice
+		}
+	}
+
+	var c *client.Client
+	var err error
+	switch {
+	case *token != "":
+		c, err = client.Resume(*addr, *room, *token)
+	case *create:
+		c, err = client.CreateRoom(*addr, *name, *difficulty, *players, *timeLimit)
+	case *room != "":
+		c, err = client.JoinRoom(*addr, *name, *room)
+	default:
+		c, err = client.Dial(*addr, *name)
+	}
+	if err != nil {
+		printColoredMessage(fmt.Sprintf("Could not join %s: %v", *addr, err), ColorRed)
+		return 1
+	}
+	welcome := c.Welcome
+	*name = welcome.Name
+	if *create && *reveal {
+		c.SetSpectatorsSeeTarget(true)
+	}
+	printColoredHeader("🌐 Joined Network Game")
+	fmt.Printf("%sRoom:%s %s%s%s (share this invite code)\n", ColorBlue, ColorReset, ColorWhite, welcome.Room, ColorReset)
+	fmt.Printf("%sDifficulty:%s %s (Range: 1-%d)\n",
+		ColorBlue, ColorReset, strings.Title(welcome.Difficulty), welcome.MaxRange)
+	fmt.Printf("%sPlayers:%s %s (%d/%d seats)\n",
+		ColorBlue, ColorReset, strings.Join(welcome.Players, ", "), len(welcome.Players), welcome.Capacity)
+	fmt.Printf("%sTime Limit:%s %s per guess\n",
+		ColorBlue, ColorReset, time.Duration(welcome.TimeLimitMS)*time.Millisecond)
+	if welcome.Host == *name {
+		printColoredMessage("Waiting for players... You are the host: type 'start' to begin early.", ColorYellow)
+	} else {
+		printColoredMessage(fmt.Sprintf("Waiting for the game to start (host: %s)...", welcome.Host), ColorYellow)
+	}
+	fmt.Printf("%sTo resume after a crash:%s guessing-game join -addr %s -room %s -token %s\n",
+		ColorCyan, ColorReset, *addr, welcome.Room, welcome.Token)
+	printSeparator()
+
+	// The connection may be replaced by a reconnect while the game runs
+	var current atomic.Pointer[client.Client]
+	current.Store(c)
+	defer func() { current.Load().Close() }()
+
+	// Forward typed lines to the server as guesses
+	go func() {
+		for {
+			line, err := input.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimSpace(line)
+			command, arg, _ := strings.Cut(line, " ")
+			arg = strings.TrimSpace(arg)
+			switch strings.ToLower(command) {
+			case "":
+				continue
+			case "help":
+				displayNetworkHelp()
+				continue
+			case "start":
+				current.Load().Start()
+				continue
+			case "reveal":
+				current.Load().SetSpectatorsSeeTarget(strings.EqualFold(arg, "on"))
+				continue
+			case "say":
+				current.Load().Chat(arg) // Never sent as a guess
+				continue
+			case "emote":
+				current.Load().Emote(arg)
+				continue
+			case "mute", "unmute":
+				current.Load().Mute(arg, strings.EqualFold(command, "mute"))
+				continue
+			}
+			current.Load().GuessText(line) // Failures surface in the read loop
+		}
+	}()
+
+	for {
+		msg, err := current.Load().Next()
+		if errors.Is(err, protocol.ErrMalformed) {
+			continue
+		}
+		if err != nil {
+			printColoredMessage(fmt.Sprintf("Connection lost: %v", err), ColorRed)
+			resumed := reconnect(*addr, welcome.Room, welcome.Token)
+			if resumed == nil {
+				return 1
+			}
+			current.Swap(resumed).Close()
+			continue
+		}
+
+		displayNetworkMessage(msg, *name)
+		if msg.Type == protocol.TypeGameOver || msg.Type == protocol.TypeGameAborted {
+			return 0
+		}
+	}
+}
+
+/*
+watchGame follows a room as a read-only spectator.
+
+Every guess is shown together with the feasible range it leaves, and the
+target too if the room's host lets spectators see it.
+
+Parameters:
+- addr string: Server address
+- room string: Invite code of the room to watch
+
+Returns:
+- int: Process exit code
+*/
+func watchGame(addr, room string) int {
+	c, err := client.Watch(addr, room)
+	if err != nil {
+		printColoredMessage(fmt.Sprintf("Could not watch %s: %v", room, err), ColorRed)
+		return 1
+	}
+	defer c.Close()
+
+	// Spectators cannot chat, but may mute noisy players
+	go func() {
+		input := bufio.NewReader(os.Stdin)
+		for {
+			line, err := input.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+			if strings.EqualFold(command, "mute") || strings.EqualFold(command, "unmute") {
+				c.Mute(strings.TrimSpace(arg), strings.EqualFold(command, "mute"))
+			}
+		}
+	}()
+
+	welcome := c.Welcome
+	printColoredHeader("👀 Spectating Network Game")
+	fmt.Printf("%sRoom:%s %s%s%s\n", ColorBlue, ColorReset, ColorWhite, welcome.Room, ColorReset)
+	fmt.Printf("%sDifficulty:%s %s (Range: 1-%d)\n",
+		ColorBlue, ColorReset, strings.Title(welcome.Difficulty), welcome.MaxRange)
+	fmt.Printf("%sPlayers:%s %s (%d/%d seats)\n",
+		ColorBlue, ColorReset, strings.Join(welcome.Players, ", "), len(welcome.Players), welcome.Capacity)
+	if welcome.Target != 0 {
+		fmt.Printf("%sTarget:%s %s%d%s (hidden from the players)\n", ColorBlue, ColorReset, ColorWhite, welcome.Target, ColorReset)
+	}
+	printColoredMessage("Watching read-only. Type 'mute <player>' to hide their chat, Ctrl+C to stop.", ColorYellow)
+	printSeparator()
+
+	for {
+		msg, err := c.Next()
+		if errors.Is(err, protocol.ErrMalformed) {
+			continue
+		}
+		if err != nil {
+			printColoredMessage(fmt.Sprintf("Connection lost: %v", err), ColorRed)
+			return 1
+		}
+
+		displayNetworkMessage(msg, "")
+		if msg.Type == protocol.TypeGameOver || msg.Type == protocol.TypeGameAborted {
+			return 0
+		}
+	}
+}
+
+/*
+reconnect tries to resume a seat after the connection dropped, retrying
+for a while in case the network is briefly unavailable.
+
+Returns:
+- *client.Client: Resumed client, or nil if every attempt failed
+*/
+func reconnect(addr, room, token string) *client.Client {
+	for attempt := 1; attempt <= ReconnectAttempts; attempt++ {
+		time.Sleep(ReconnectDelay)
+		printColoredMessage(fmt.Sprintf("Reconnecting (%d/%d)...", attempt, ReconnectAttempts), ColorYellow)
+
+		c, err := client.Resume(addr, room, token)
+		if err == nil {
+			return c
+		}
+		var remote *protocol.RemoteError
+		if errors.As(err, &remote) {
+			printColoredMessage(fmt.Sprintf("Could not resume: %s", remote.Message), ColorRed)
+			return nil // The seat is gone; retrying will not help
+		}
+	}
+	printColoredMessage("Giving up. Your seat is held for a while; rejoin with the command above.", ColorRed)
+	return nil
+}
+
+/*
+displayNetworkMessage renders a server message using the same colors and
+phrasing as the local game.
+
+Parameters:
+- msg protocol.Message: Message received from the server
+- self string: This client's player name
+*/
+func displayNetworkMessage(msg protocol.Message, self string) {
+	switch msg.Type {
+	case protocol.TypePlayerJoined:
+		printColoredMessage(fmt.Sprintf("%s joined (%d seated)", msg.Player, len(msg.Players)), ColorGreen)
+	case protocol.TypePlayerLeft:
+		printColoredMessage(fmt.Sprintf("%s left (%d seated)", msg.Player, len(msg.Players)), ColorYellow)
+	case protocol.TypeGameStarted:
+		printColoredMessage(fmt.Sprintf("Game started! Turn order: %s", strings.Join(msg.Players, ", ")), ColorPurple)
+	case protocol.TypeTurnStart:
+		if msg.YourTurn {
+			fmt.Printf("%s[Your Turn]%s Enter your guess (%ds left) or 'help': ",
+				ColorBlue, ColorReset, (msg.TimeLeftMS+999)/1000)
+		} else {
+			fmt.Printf("%s[%s's Turn]%s waiting...\n", ColorBlue, msg.Player, ColorReset)
+		}
+	case protocol.TypeHint:
+		if msg.Valid {
+			printColoredMessage("000 "+msg.Hint, ColorYellow)
+		} else if !msg.Correct {
+			printColoredMessage(":( "+msg.Hint, ColorRed)
+		}
+	case protocol.TypeGuess:
+		if msg.Valid && !msg.Correct {
+			fmt.Printf("  %s guessed %d: %s%s%s%s\n", msg.Player, msg.Value, ColorYellow, msg.Hint, ColorReset, rangeSuffix(msg))
+		} else if !msg.Valid {
+			fmt.Printf("  %s made an invalid guess\n", msg.Player)
+		}
+	case protocol.TypeTimeout:
+		if msg.Player == self {
+			printColoredMessage("\nTime's up! Your turn is skipped.", ColorRed)
+		} else {
+			printColoredMessage(fmt.Sprintf("Time's up, %s! Turn skipped.", msg.Player), ColorRed)
+		}
+	case protocol.TypeWin:
+		printColoredMessage(fmt.Sprintf(" %s wins with %d attempts for %d points! ",
+			msg.Player, msg.Attempts, msg.Score), ColorGreen)
+	case protocol.TypeGameOver:
+		printColoredHeader("Game Over")
+		fmt.Printf("  Target Number: %s%d%s\n", ColorWhite, msg.Target, ColorReset)
+		fmt.Printf("  Winner: %s%s%s\n", ColorGreen, msg.Winner, ColorReset)
+		printSeparator()
+	case protocol.TypePlayerAway:
+		printColoredMessage(fmt.Sprintf("%s disconnected; their turns are skipped until they return", msg.Player), ColorYellow)
+	case protocol.TypePlayerBack:
+		printColoredMessage(fmt.Sprintf("%s is back", msg.Player), ColorGreen)
+	case protocol.TypeHistory:
+		printColoredMessage(fmt.Sprintf("%d turns played so far:", len(msg.History)), ColorCyan)
+		for _, turn := range msg.History {
+			switch {
+			case turn.Valid:
+				fmt.Printf("  %s guessed %d: %s\n", turn.Player, turn.Value, turn.Hint)
+			case turn.TimedOut:
+				fmt.Printf("  %s: %s\n", turn.Player, turn.Hint)
+			default:
+				fmt.Printf("  %s made an invalid guess\n", turn.Player)
+			}
+		}
+		if len(msg.History) > 0 {
+			fmt.Printf("  Remaining range: %d-%d\n", msg.Low, msg.High)
+		}
+	case protocol.TypeSettings:
+		if msg.SpectatorsSeeTarget {
+			printColoredMessage("Spectators can now see the target.", ColorCyan)
+		} else {
+			printColoredMessage("The target is now hidden from spectators.", ColorCyan)
+		}
+	case protocol.TypeGameAborted:
+		printColoredMessage("The game was abandoned.", ColorYellow)
+	case protocol.TypeChat:
+		fmt.Printf("%s%s:%s %s\n", ColorCyan, msg.Player, ColorReset, chatLine(msg.Text, msg.Emote))
+	case protocol.TypeAnnouncement:
+		printColoredMessage("📢 Server: "+msg.Text, ColorPurple)
+	case protocol.TypeError:
+		printColoredMessage(fmt.Sprintf("Server: %s", msg.Message), ColorRed)
+	}
+}
+
+/*
+displayNetworkHelp lists the commands available in a networked game,
+followed by the usual gameplay tips.
+*/
+func displayNetworkHelp() {
+	fmt.Printf("\n%s Network Commands%s\n", ColorPurple, ColorReset)
+	fmt.Printf("  • say <message>     Chat with the room\n")
+	fmt.Printf("  • emote <name>      Quick emote: %s\n", strings.Join(emoteNames(), ", "))
+	fmt.Printf("  • mute <player>     Hide a player's chat (unmute <player> to undo)\n")
+	fmt.Printf("  • start             Begin early (host only)\n")
+	fmt.Printf("  • reveal on|off     Show spectators the target (host only)\n")
+	displayInGameHelp()
+}
+
+/*
+rangeSuffix describes the feasible range carried by a guess announcement,
+plus the target when the server reveals it to spectators.
+*/
+func rangeSuffix(msg protocol.Message) string {
+	switch {
+	case msg.Low == 0:
+		return ""
+	case msg.Target != 0:
+		return fmt.Sprintf(" %s(range %d-%d, target %d)%s", ColorCyan, msg.Low, msg.High, msg.Target, ColorReset)
+	default:
+		return fmt.Sprintf(" %s(range %d-%d)%s", ColorCyan, msg.Low, msg.High, ColorReset)
+	}
+}
+
+/*
+displayRoomList prints the lobby listing.
+
+Parameters:
+- rooms []protocol.RoomInfo: Open rooms from the server
+*/
+func displayRoomList(rooms []protocol.RoomInfo) {
+	printColoredHeader("🚪 Open Rooms")
+	if len(rooms) == 0 {
+		printColoredMessage("No open rooms - create one!", ColorYellow)
+	}
+	for _, room := range rooms {
+		fmt.Printf("  %s%s%s  %-7s (1-%d)  %d/%d players  host: %s\n",
+			ColorWhite, room.Code, ColorReset, strings.Title(room.Difficulty), room.MaxRange,
+			room.Players, room.Capacity, room.Host)
+	}
+	printSeparator()
+}
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Leaderboard query constants - Time windows and ranking modes accepted by
+// the statistics dashboard and the leaderboard subcommand
+const (
+	WindowAllTime = "all"    // Every recorded game
+	WindowToday   = "today"  // Games since local midnight
+	WindowWeek    = "week"   // Games since Monday of the current week
+	WindowMonth   = "month"  // Games since the first day of the current month
+	WindowSeason  = "season" // Games in the current calendar quarter
+
+	RankByTotal   = "total"   // Sum of all points earned
+	RankByAverage = "average" // Points earned per game played
+	RankByBest    = "best"    // Highest single-game score
+
+	AllDifficulties = "all" // Difficulty filter matching every game
+)
+
+// Difficulties lists the selectable difficulty levels in display order
+var Difficulties = []string{"easy", "medium", "hard"}
+
+/*
+LeaderboardQuery describes a filtered and ranked view over the game history.
+
+Fields:
+- Difficulty: easy/medium/hard, or AllDifficulties (empty means all)
+- Window: One of the Window* constants (empty means all time)
+- Mode: One of the RankBy* constants (empty means total)
+*/
+type LeaderboardQuery struct {
+	Difficulty string
+	Window     string
+	Mode       string
+}
+
+/*
+LeaderboardEntry aggregates one player's results within a leaderboard query.
+
+Every ranking mode is computed at once so displays can show supporting
+figures next to the metric the table is sorted by.
+*/
+type LeaderboardEntry struct {
+	Name         string  `json:"name"`          // Player name
+	Games        int     `json:"games"`         // Games the player took part in
+	Wins         int     `json:"wins"`          // Games the player won
+	TotalScore   int     `json:"total_score"`   // Sum of winning scores
+	BestScore    int     `json:"best_score"`    // Highest single-game score, before handicaps
+	AverageScore float64 `json:"average_score"` // TotalScore divided by Games
+}
+
+/*
+Metric returns the value an entry is ranked by for the given mode.
+
+Parameters:
+- mode string: One of the RankBy* constants
+
+Returns:
+- float64: Ranking value (higher is better)
+*/
+func (e LeaderboardEntry) Metric(mode string) float64 {
+	switch mode {
+	case RankByAverage:
+		return e.AverageScore
+	case RankByBest:
+		return float64(e.BestScore)
+	default:
+		return float64(e.TotalScore)
+	}
+}
+
+/*
+normalize fills in defaults and validates every field of the query.
+
+Returns:
+- LeaderboardQuery: Query with defaults applied and values lowercased
+- error: Description of the first invalid field
+*/
+func (q LeaderboardQuery) normalize() (LeaderboardQuery, error) {
+	q.Difficulty = strings.ToLower(strings.TrimSpace(q.Difficulty))
+	q.Window = strings.ToLower(strings.TrimSpace(q.Window))
+	q.Mode = strings.ToLower(strings.TrimSpace(q.Mode))
+
+	if q.Difficulty == "" {
+		q.Difficulty = AllDifficulties
+	}
+	if q.Window == "" {
+		q.Window = WindowAllTime
+	}
+	if q.Mode == "" {
+		q.Mode = RankByTotal
+	}
+
+	if q.Difficulty != AllDifficulties && q.Difficulty != DifficultyAdaptive && !contains(Difficulties, q.Difficulty) {
+		return q, fmt.Errorf("unknown difficulty %q (want easy, medium, hard, adaptive or all)", q.Difficulty)
+	}
+	switch q.Window {
+	case WindowAllTime, WindowToday, WindowWeek, WindowMonth, WindowSeason:
+	default:
+		return q, fmt.Errorf("unknown window %q (want all, today, week, month or season)", q.Window)
+	}
+	switch q.Mode {
+	case RankByTotal, RankByAverage, RankByBest:
+	default:
+		return q, fmt.Errorf("unknown ranking mode %q (want total, average or best)", q.Mode)
+	}
+
+	return q, nil
+}
+
+/*
+windowStart returns the earliest timestamp included in a time window.
+
+Window Boundaries (all in the local time zone of now):
+- today: Midnight of the current day
+- week: Midnight of the most recent Monday
+- month: Midnight of the first day of the month
+- season: Midnight of the first day of the calendar quarter
+- all: The zero time, matching every game
+
+Parameters:
+- window string: One of the Window* constants
+- now time.Time: Reference point for relative windows
+
+Returns:
+- time.Time: Inclusive lower bound for session timestamps
+*/
+func windowStart(window string, now time.Time) time.Time {
+	year, month, day := now.Date()
+	midnight := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+
+	switch window {
+	case WindowToday:
+		return midnight
+	case WindowWeek:
+		// time.Weekday counts from Sunday; shift so Monday starts the week
+		offset := (int(now.Weekday()) + 6) % 7
+		return midnight.AddDate(0, 0, -offset)
+	case WindowMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, now.Location())
+	case WindowSeason:
+		quarterStart := time.Month((int(month)-1)/3*3 + 1)
+		return time.Date(year, quarterStart, 1, 0, 0, 0, 0, now.Location())
+	default:
+		return time.Time{}
+	}
+}
+
+/*
+sessionPlayers returns everyone who took part in a session.
+
+Sessions recorded before participant tracking existed only know their
+winner, so the winner is used as the sole participant for those.
+*/
+func sessionPlayers(session GameSession) []string {
+	if len(session.Players) > 0 {
+		return session.Players
+	}
+	if session.Winner != "" {
+		return []string{session.Winner}
+	}
+	return nil
+}
+
+/*
+queryLeaderboard builds a ranked leaderboard from the game history.
+
+Only winners score points in a session, but every participant is counted as
+having played, so average-per-game rankings reward consistent winners over
+players who simply play a lot.
+
+Parameters:
+- history []GameSession: Complete session history
+- query LeaderboardQuery: Difficulty, window and ranking mode
+- now time.Time: Reference point for relative time windows
+
+Returns:
+- []LeaderboardEntry: Entries sorted by the query's ranking mode
+- error: Validation failure for the query
+*/
+func queryLeaderboard(history []GameSession, query LeaderboardQuery, now time.Time) ([]LeaderboardEntry, error) {
+	query, err := query.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	since := windowStart(query.Window, now)
+	entries := make(map[string]*LeaderboardEntry)
+	entryFor := func(name string) *LeaderboardEntry {
+		if entry, exists := entries[name]; exists {
+			return entry
+		}
+		entry := &LeaderboardEntry{Name: name}
+		entries[name] = entry
+		return entry
+	}
+
+	for _, session := range history {
+		if query.Difficulty != AllDifficulties && session.Difficulty != query.Difficulty {
+			continue
+		}
+		if session.Timestamp.Before(since) {
+			continue
+		}
+
+		for _, player := range sessionPlayers(session) {
+			entryFor(player).Games++
+		}
+		if session.Winner != "" {
+			winner := entryFor(session.Winner)
+			winner.Wins++
+			winner.TotalScore += session.FinalScore
+			winner.BestScore = max(winner.BestScore, comparableScore(session))
+		}
+	}
+
+	ranked := make([]LeaderboardEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Games > 0 {
+			entry.AverageScore = float64(entry.TotalScore) / float64(entry.Games)
+		}
+		ranked = append(ranked, *entry)
+	}
+
+	// Sort by the requested metric, breaking ties by name for stable output
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i].Metric(query.Mode), ranked[j].Metric(query.Mode)
+		if a != b {
+			return a > b
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+
+	return ranked, nil
+}
+
+/*
+rankMedal returns the medal or ordinal shown next to a leaderboard position.
+
+Parameters:
+- position int: Zero-based rank
+
+Returns:
+- string: Medal emoji or "N." ordinal
+- string: ANSI color for the player's name
+*/
+func rankMedal(position int) (string, string) {
+	switch position {
+	case 0:
+		return "🥇", ColorYellow
+	case 1:
+		return "🥈", ColorWhite
+	case 2:
+		return "🥉", ColorYellow
+	default:
+		return fmt.Sprintf("%d.", position+1), ColorCyan
+	}
+}
+
+/*
+describeLeaderboardQuery produces a human-readable title for a query.
+
+Example: "Hard • This Week • Average per Game"
+*/
+func describeLeaderboardQuery(query LeaderboardQuery) string {
+	difficulty := "All Difficulties"
+	if query.Difficulty != "" && query.Difficulty != AllDifficulties {
+		difficulty = strings.Title(query.Difficulty)
+	}
+
+	window := "All Time"
+	switch query.Window {
+	case WindowToday:
+		window = "Today"
+	case WindowWeek:
+		window = "This Week"
+	case WindowMonth:
+		window = "This Month"
+	case WindowSeason:
+		window = "This Season"
+	}
+
+	mode := "Total Score"
+	switch query.Mode {
+	case RankByAverage:
+		mode = "Average per Game"
+	case RankByBest:
+		mode = "Best Single Game"
+	}
+
+	return fmt.Sprintf("%s • %s • %s", difficulty, window, mode)
+}
+
+/*
+displayLeaderboard prints ranked leaderboard entries with medal formatting.
+
+The ranking metric is printed prominently while the remaining figures are
+shown as supporting context, keeping every mode comparable at a glance.
+
+Parameters:
+- entries []LeaderboardEntry: Ranked entries from queryLeaderboard
+- mode string: Ranking mode used to order the entries
+- limit int: Maximum rows to print (0 or less prints all)
+*/
+func displayLeaderboard(entries []LeaderboardEntry, mode string, limit int) {
+	if len(entries) == 0 {
+		fmt.Printf("  %sNo games recorded for this leaderboard yet.%s\n", ColorYellow, ColorReset)
+		return
+	}
+
+	for i, entry := range entries {
+		if limit > 0 && i >= limit {
+			break
+		}
+
+		medal, color := rankMedal(i)
+		metric := fmt.Sprintf("%d points", int(entry.Metric(mode)))
+		if mode == RankByAverage {
+			metric = fmt.Sprintf("%.1f points/game", entry.AverageScore)
+		}
+
+		fmt.Printf("  %s %s%s%s: %s%s%s (%d games, %d wins, best %d)\n",
+			medal, color, entry.Name, ColorReset, ColorGreen, metric, ColorReset,
+			entry.Games, entry.Wins, entry.BestScore)
+	}
+}
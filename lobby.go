@@ -0,0 +1,705 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gaming/my-guessing-game/protocol"
+)
+
+// Lobby constants - Invite codes avoid characters that are easily confused
+// when read aloud or copied from a screen
+const (
+	InviteCodeLength = 6
+	inviteAlphabet   = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // No 0/O or 1/I
+
+	FinishedRoomTTL       = 10 * time.Minute // How long finished rooms stay pollable
+	IdleRoomTTL           = 30 * time.Minute // How long a room may wait for its game to start
+	DefaultReconnectGrace = time.Minute      // How long a dropped player's seat is held
+)
+
+// Lobby errors returned to network clients
+var (
+	ErrRoomNotFound    = errors.New("no such room")
+	ErrNoOpenRoom      = errors.New("no open room to join")
+	ErrInvalidSettings = errors.New("invalid room settings")
+	ErrUnknownSession  = errors.New("missing or unknown session token")
+	ErrNoSuchPlayer    = errors.New("no such player in this room")
+	ErrKicked          = errors.New("removed from the room by the server operator")
+	ErrSeatedSpectator = errors.New("players cannot spectate their own room")
+)
+
+/*
+RoomSettings configures a new room. Zero values take the lobby defaults.
+
+SpectatorsSeeTarget lets read-only viewers see the target while the game is
+played - fun for an audience. Players cannot use it to peek: spectator views
+opened with a seated player's token, or from the IP address of a seated
+player, are refused or never shown the target (see CheckSpectator).
+*/
+type RoomSettings struct {
+	Difficulty string
+	Capacity   int
+	TimeLimit  time.Duration
+
+	SpectatorsSeeTarget bool
+}
+
+/*
+withDefaults fills zero fields from defaults and validates the result.
+
+Returns:
+- RoomSettings: Complete settings
+- error: ErrInvalidSettings describing the first invalid field
+*/
+func (s RoomSettings) withDefaults(defaults RoomSettings) (RoomSettings, error) {
+	s.Difficulty = strings.ToLower(strings.TrimSpace(s.Difficulty))
+	if s.Difficulty == "" {
+		s.Difficulty = defaults.Difficulty
+	}
+	if s.Capacity == 0 {
+		s.Capacity = defaults.Capacity
+	}
+	if s.TimeLimit == 0 {
+		s.TimeLimit = defaults.TimeLimit
+	}
+
+	switch {
+	case !contains(Difficulties, s.Difficulty):
+		return s, fmt.Errorf("%w: unknown difficulty %q (want easy, medium or hard)", ErrInvalidSettings, s.Difficulty)
+	case s.Capacity < 1 || s.Capacity > MaxPlayers:
+		return s, fmt.Errorf("%w: players must be between 1 and %d", ErrInvalidSettings, MaxPlayers)
+	case s.TimeLimit <= 0:
+		return s, fmt.Errorf("%w: time limit must be positive", ErrInvalidSettings)
+	}
+	return s, nil
+}
+
+/*
+Lobby hosts many rooms at once and records their results.
+
+Both the TCP server and the HTTP API create and find rooms through the
+lobby, so a browser player and a terminal player can share a room.
+*/
+type Lobby struct {
+	dataPath string
+	defaults RoomSettings
+	grace    time.Duration // Reconnection grace period for dropped players
+	metrics  *serverMetrics
+
+	mu    sync.Mutex
+	rooms map[string]*Room
+
+	saveMu sync.Mutex // Serializes save file updates from concurrent rooms
+}
+
+/*
+Room is one hosted game together with the sessions of its players.
+
+The host is the first seated player (see Match.Host).
+
+Sessions and Connections:
+Every seated player holds a session token. Connection-based clients (TCP)
+attach a live connection to their session; if it drops after the game has
+started, the seat is held for the lobby's grace period while the player's
+turns are skipped, and resuming with the token reclaims it. Stateless
+clients (HTTP) simply present the token with each request.
+*/
+type Room struct {
+	Code    string
+	Match   *Match
+	Created time.Time
+
+	lobby *Lobby
+
+	mu       sync.Mutex
+	tokens   map[string]string // Session token -> player name
+	kicked   map[string]bool   // Session tokens revoked by Kick
+	present  map[string]bool   // Seated players that have not left
+	finished time.Time         // When the result was recorded; zero while playing
+
+	spectators   int               // Open read-only views
+	revealTarget bool              // Whether spectators see the target during play
+	playerHosts  map[string]string // Player -> network host they last played from
+
+	chatLimits map[string]*rateLimiter // Player -> chat rate limit
+
+	conns   map[string]int         // Player -> generation of their live connection
+	closers map[string]func()      // Player -> closes their live connection
+	grace   map[string]*time.Timer // Player -> pending forfeit while disconnected
+	connGen int
+}
+
+/*
+newLobby creates an empty lobby.
+
+Parameters:
+- dataPath string: Save file used for post-game evaluation and results
+- defaults RoomSettings: Settings for fields a room creator leaves empty
+- grace time.Duration: How long a disconnected player may take to resume
+
+Returns:
+- *Lobby: Lobby with no rooms
+*/
+func newLobby(dataPath string, defaults RoomSettings, grace time.Duration) *Lobby {
+	return &Lobby{
+		dataPath: dataPath,
+		defaults: defaults,
+		grace:    grace,
+		metrics:  newServerMetrics(),
+		rooms:    make(map[string]*Room),
+	}
+}
+
+/*
+CreateRoom opens a new room with a fresh invite code.
+
+Parameters:
+- settings RoomSettings: Requested settings (zero fields use the defaults)
+
+Returns:
+- *Room: Room waiting for players
+- error: ErrInvalidSettings or a save file error
+*/
+func (l *Lobby) CreateRoom(settings RoomSettings) (*Room, error) {
+	settings, err := settings.withDefaults(l.defaults)
+	if err != nil {
+		return nil, err
+	}
+
+	saveData, err := loadSaveData(l.dataPath)
+	if err != nil {
+		return nil, err
+	}
+
+	room := &Room{
+		Created: time.Now(),
+		lobby:   l,
+		tokens:  make(map[string]string),
+		kicked:  make(map[string]bool),
+		present: make(map[string]bool),
+		conns:   make(map[string]int),
+		closers: make(map[string]func()),
+		grace:   make(map[string]*time.Timer),
+
+		revealTarget: settings.SpectatorsSeeTarget,
+		playerHosts:  make(map[string]string),
+		chatLimits:   make(map[string]*rateLimiter),
+	}
+
+	l.mu.Lock()
+	l.pruneLocked(room.Created)
+	for room.Code == "" || l.rooms[room.Code] != nil {
+		room.Code = newInviteCode()
+	}
+	room.Match = newMatch(room.Code, settings.Difficulty, settings.Capacity, settings.TimeLimit, saveData)
+
+	// Listen before the room is published, so no join or start can be missed
+	events, _ := room.Match.Subscribe()
+	go logMatchEvents(room.Code, events)
+	events, stop := room.Match.SubscribeAll()
+	go func() {
+		defer stop()
+		l.metrics.observe(settings.Difficulty, events)
+	}()
+
+	l.rooms[room.Code] = room
+	l.mu.Unlock()
+	go l.recordWhenDone(room)
+
+	printColoredMessage(fmt.Sprintf("[%s] Room created: %s", room.Code, room.Match.Summary()), ColorGreen)
+	return room, nil
+}
+
+/*
+Room finds a room by invite code (case-insensitive).
+
+Returns:
+- *Room: The room
+- error: ErrRoomNotFound
+*/
+func (l *Lobby) Room(code string) (*Room, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	room, exists := l.rooms[strings.ToUpper(strings.TrimSpace(code))]
+	if !exists {
+		return nil, ErrRoomNotFound
+	}
+	return room, nil
+}
+
+/*
+OpenRoom picks the oldest room that is still accepting players, for
+clients that join without an invite code.
+
+Returns:
+- *Room: A joinable room
+- error: ErrNoOpenRoom
+*/
+func (l *Lobby) OpenRoom() (*Room, error) {
+	for _, room := range l.Rooms() {
+		if info := room.Info(); !info.Started && info.Players < info.Capacity {
+			return room, nil
+		}
+	}
+	return nil, ErrNoOpenRoom
+}
+
+/*
+Rooms returns every room, oldest first.
+*/
+func (l *Lobby) Rooms() []*Room {
+	l.mu.Lock()
+	l.pruneLocked(time.Now())
+	rooms := make([]*Room, 0, len(l.rooms))
+	for _, room := range l.rooms {
+		rooms = append(rooms, room)
+	}
+	l.mu.Unlock()
+
+	sort.Slice(rooms, func(i, j int) bool {
+		return rooms[i].Created.Before(rooms[j].Created)
+	})
+	return rooms
+}
+
+/*
+OpenRooms lists the rooms a new player could join, for the lobby listing.
+*/
+func (l *Lobby) OpenRooms() []protocol.RoomInfo {
+	var open []protocol.RoomInfo
+	for _, room := range l.Rooms() {
+		if info := room.Info(); !info.Started && info.Players < info.Capacity {
+			open = append(open, info)
+		}
+	}
+	return open
+}
+
+/*
+recordWhenDone saves a room's result once its game has a winner.
+*/
+func (l *Lobby) recordWhenDone(room *Room) {
+	<-room.Match.Done()
+
+	state, err := room.Match.FinishedState()
+	if errors.Is(err, ErrMatchAborted) {
+		return // Nobody won; nothing to record
+	}
+	if err == nil {
+		l.saveMu.Lock()
+		err = recordFinishedGame(l.dataPath, state)
+		l.saveMu.Unlock()
+	}
+	if err != nil {
+		printColoredMessage(fmt.Sprintf("[%s] Could not save game data: %v", room.Code, err), ColorYellow)
+	}
+
+	room.mu.Lock()
+	room.finished = time.Now()
+	room.mu.Unlock()
+}
+
+// pruneLocked forgets stale rooms; caller holds l.mu
+func (l *Lobby) pruneLocked(now time.Time) {
+	for code, room := range l.rooms {
+		room.mu.Lock()
+		finished := room.finished
+		room.mu.Unlock()
+
+		stale := !finished.IsZero() && now.Sub(finished) > FinishedRoomTTL
+		idle := !room.Match.Snapshot().Started && now.Sub(room.Created) > IdleRoomTTL
+		if stale || idle {
+			room.Match.Abort() // No-op for finished games
+			delete(l.rooms, code)
+		}
+	}
+}
+
+// remove closes a room that everyone has left
+func (l *Lobby) remove(room *Room) {
+	room.Match.Abort() // No-op for finished games
+
+	l.mu.Lock()
+	if l.rooms[room.Code] == room {
+		delete(l.rooms, room.Code)
+		printColoredMessage(fmt.Sprintf("[%s] Room closed", room.Code), ColorYellow)
+	}
+	l.mu.Unlock()
+}
+
+/*
+Join seats a player and opens a session for them.
+
+The game starts automatically once every seat is taken; the host may also
+start it earlier.
+
+Parameters:
+- name string: Requested display name
+
+Returns:
+- string: Session token identifying the player in later requests
+- error: Any error from Match.Join
+*/
+func (r *Room) Join(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if err := r.Match.Join(name); err != nil {
+		return "", err
+	}
+
+	token := newToken(16)
+	r.mu.Lock()
+	r.tokens[token] = name
+	r.present[name] = true
+	r.mu.Unlock()
+
+	if r.Match.IsFull() {
+		r.Match.Start() // ErrMatchStarted just means another player won the race
+	}
+	return token, nil
+}
+
+/*
+Leave ends a player's session.
+
+Before the game starts the seat is freed. When the last player leaves, the
+room is closed and any game in progress is aborted.
+*/
+func (r *Room) Leave(name string) {
+	r.Match.Leave(name)
+
+	r.mu.Lock()
+	delete(r.present, name)
+	if timer := r.grace[name]; timer != nil {
+		timer.Stop()
+		delete(r.grace, name)
+	}
+	for token, player := range r.tokens {
+		if player == name {
+			delete(r.tokens, token)
+		}
+	}
+	empty := len(r.present) == 0
+	r.mu.Unlock()
+
+	if empty {
+		r.lobby.remove(r)
+	}
+}
+
+/*
+Kick removes a player on the server operator's behalf.
+
+The player's session is revoked and their connection closed. Before the
+game starts the seat is freed; during the game it stays in the turn order
+(so turns stay stable) but is skipped, like a disconnected player's.
+
+Returns:
+- error: ErrNoSuchPlayer if the player is not in the room
+*/
+func (r *Room) Kick(name string) error {
+	r.mu.Lock()
+	if !r.present[name] {
+		r.mu.Unlock()
+		return ErrNoSuchPlayer
+	}
+	closeConn := r.closers[name]
+	delete(r.conns, name) // The dropped connection must not start a grace period
+	delete(r.closers, name)
+	for token, player := range r.tokens {
+		if player == name {
+			r.kicked[token] = true
+		}
+	}
+	r.mu.Unlock()
+
+	if r.Match.Snapshot().Started {
+		r.Match.SetAway(name, true)
+	}
+	r.Leave(name)
+	if closeConn != nil {
+		closeConn()
+	}
+	return nil
+}
+
+/*
+Attach registers a live connection for a player's session.
+
+Any previous connection of the same player is closed, so a client that
+reconnects before the server notices the old connection dropped takes over
+cleanly. A pending forfeit is cancelled and the player's turns are played
+again.
+
+Parameters:
+- name string: Seated player
+- closeConn func(): Closes the connection (used on takeover)
+
+Returns:
+- func(): Detach function to call when the connection ends
+*/
+func (r *Room) Attach(name string, closeConn func()) func() {
+	r.mu.Lock()
+	r.connGen++
+	gen := r.connGen
+	previous := r.closers[name]
+	r.conns[name] = gen
+	r.closers[name] = closeConn
+	if timer := r.grace[name]; timer != nil {
+		timer.Stop()
+		delete(r.grace, name)
+	}
+	r.mu.Unlock()
+
+	if previous != nil {
+		previous()
+	}
+	r.Match.SetAway(name, false)
+
+	return func() {
+		r.mu.Lock()
+		current := r.conns[name] == gen
+		if current {
+			delete(r.conns, name)
+			delete(r.closers, name)
+		}
+		r.mu.Unlock()
+
+		if current {
+			r.disconnect(name)
+		}
+	}
+}
+
+/*
+disconnect handles a dropped connection.
+
+Before the game starts (or after it ends) the player simply leaves. During
+the game their seat is held for the grace period while their turns are
+skipped; if they have not resumed by then, the session is forfeited.
+*/
+func (r *Room) disconnect(name string) {
+	snapshot := r.Match.Snapshot()
+	if !snapshot.Started || snapshot.Finished {
+		r.Leave(name)
+		return
+	}
+
+	r.Match.SetAway(name, true)
+	r.mu.Lock()
+	r.grace[name] = time.AfterFunc(r.lobby.grace, func() { r.forfeit(name) })
+	r.mu.Unlock()
+}
+
+// forfeit ends the session of a player who did not resume in time
+func (r *Room) forfeit(name string) {
+	r.mu.Lock()
+	_, connected := r.conns[name]
+	r.mu.Unlock()
+
+	if !connected {
+		r.Leave(name) // Turns stay skipped; the seat keeps turn order stable
+	}
+}
+
+/*
+Watch registers a read-only spectator view of the room.
+
+Returns:
+- func(): Function to call when the spectator leaves
+*/
+func (r *Room) Watch() func() {
+	r.mu.Lock()
+	r.spectators++
+	r.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.mu.Lock()
+			r.spectators--
+			r.mu.Unlock()
+		})
+	}
+}
+
+/*
+SetRevealTarget lets the host decide whether spectators see the target.
+
+Parameters:
+- by string: Player requesting the change
+- enabled bool: New setting
+
+Returns:
+- error: ErrNotHost if the player is not the host
+*/
+func (r *Room) SetRevealTarget(by string, enabled bool) error {
+	if by != r.Match.Host() {
+		return ErrNotHost
+	}
+	r.mu.Lock()
+	r.revealTarget = enabled
+	r.mu.Unlock()
+	return nil
+}
+
+/*
+PlayingFrom records the network address a seated player plays from, so
+spectator views from the same host can be kept from seeing the target.
+
+Parameters:
+- player string: Seated player
+- addr string: Remote address of their connection or request
+*/
+func (r *Room) PlayingFrom(player, addr string) {
+	r.mu.Lock()
+	r.playerHosts[player] = remoteHost(addr)
+	r.mu.Unlock()
+}
+
+/*
+CheckSpectator decides whether a spectator view may open. Room codes are
+public, so a seated player could otherwise watch their own game and, with
+spectators_see_target on, read the target.
+
+Parameters:
+- token string: Session token presented with the request; may be empty
+- addr string: Remote address of the spectator
+
+Returns:
+  - error: ErrSeatedSpectator if the token belongs to a seat in the room, or
+    if the target is revealed and a seated player plays from the same host
+*/
+func (r *Room) CheckSpectator(token, addr string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, seated := r.tokens[token]; seated || r.kicked[token] {
+		return ErrSeatedSpectator
+	}
+	if r.revealTarget && r.seatedHost(remoteHost(addr)) {
+		return ErrSeatedSpectator
+	}
+	return nil
+}
+
+// seatedHost reports whether a seated player plays from host; r.mu must be held
+func (r *Room) seatedHost(host string) bool {
+	for _, playerHost := range r.playerHosts {
+		if playerHost == host {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+SpectatorTarget returns the target for a spectator view.
+
+The check is repeated for every message, so a view opened before its host
+joined the game, or before the target was revealed, never sees it.
+
+Parameters:
+- addr string: Remote address of the spectator
+
+Returns:
+- int: The target, or 0 while it is hidden from this spectator
+*/
+func (r *Room) SpectatorTarget(addr string) int {
+	r.mu.Lock()
+	reveal := r.revealTarget && !r.seatedHost(remoteHost(addr))
+	r.mu.Unlock()
+
+	if !reveal {
+		return 0
+	}
+	return r.Match.Target()
+}
+
+// remoteHost strips the port from a remote address
+func remoteHost(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+/*
+Player resolves a session token, for requests and for resuming a seat.
+
+Returns:
+  - string: Player name
+  - error: ErrKicked for kicked players, ErrUnknownSession for other unknown
+    or forfeited tokens
+*/
+func (r *Room) Player(token string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.kicked[token] {
+		return "", ErrKicked
+	}
+	player, exists := r.tokens[token]
+	if !exists {
+		return "", ErrUnknownSession
+	}
+	return player, nil
+}
+
+/*
+Info summarizes the room for the lobby listing.
+*/
+func (r *Room) Info() protocol.RoomInfo {
+	snapshot := r.Match.Snapshot()
+	r.mu.Lock()
+	spectators, reveal := r.spectators, r.revealTarget
+	r.mu.Unlock()
+
+	return protocol.RoomInfo{
+		Code:        r.Code,
+		Host:        snapshot.Host,
+		Difficulty:  snapshot.Difficulty,
+		MaxRange:    snapshot.MaxRange,
+		TimeLimitMS: snapshot.TimeLimitMS,
+		Players:     len(snapshot.Players),
+		Capacity:    snapshot.Capacity,
+		Started:     snapshot.Started,
+		Spectators:  spectators,
+
+		SpectatorsSeeTarget: reveal,
+	}
+}
+
+/*
+newInviteCode returns a random room code such as "K7QX2M".
+*/
+func newInviteCode() string {
+	buf := make([]byte, InviteCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		panic(fmt.Sprintf("crypto/rand failed: %v", err))
+	}
+	for i, b := range buf {
+		buf[i] = inviteAlphabet[int(b)%len(inviteAlphabet)]
+	}
+	return string(buf)
+}
+
+/*
+newToken returns a random hex string of n bytes, used for session tokens.
+*/
+func newToken(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		panic(fmt.Sprintf("crypto/rand failed: %v", err))
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"strings"
+)
+
+// Logging constants - Structured logs are off by default so the terminal
+// stays reserved for players; the environment switches them on in every mode
+const (
+	LogDestEnv   = "GUESSING_GAME_LOG"        // "stderr", "stdout" or a file path to append to
+	LogFormatEnv = "GUESSING_GAME_LOG_FORMAT" // "text" (default) or "json"
+	LogLevelEnv  = "GUESSING_GAME_LOG_LEVEL"  // "debug", "info" (default), "warn" or "error"
+)
+
+/*
+setupLogging installs the process-wide structured logger described by the
+GUESSING_GAME_LOG* environment variables.
+
+Logs record what happened - sessions, turns, timeouts, scoring and save
+file operations - for operators and debugging; they are never shown to
+players unless the destination is the terminal. Without GUESSING_GAME_LOG
+nothing is logged.
+
+Returns:
+- func(): Flushes and closes the destination; call before exiting
+- error: Invalid setting or unopenable log file (logging stays disabled)
+*/
+func setupLogging() (func(), error) {
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.Level(math.MaxInt)})))
+
+	dest := strings.TrimSpace(os.Getenv(LogDestEnv))
+	if dest == "" {
+		return func() {}, nil
+	}
+
+	var level slog.Level
+	if text := os.Getenv(LogLevelEnv); text != "" {
+		if err := level.UnmarshalText([]byte(text)); err != nil {
+			return func() {}, fmt.Errorf("%s: unknown level %q (want debug, info, warn or error)", LogLevelEnv, text)
+		}
+	}
+
+	var out io.Writer
+	closeLog := func() {}
+	switch dest {
+	case "stderr":
+		out = os.Stderr
+	case "stdout":
+		out = os.Stdout
+	default:
+		file, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return func() {}, fmt.Errorf("opening log file: %w", err)
+		}
+		out = file
+		closeLog = func() { file.Close() }
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch format := strings.ToLower(os.Getenv(LogFormatEnv)); format {
+	case "", "text":
+		handler = slog.NewTextHandler(out, options)
+	case "json":
+		handler = slog.NewJSONHandler(out, options)
+	default:
+		closeLog()
+		return func() {}, fmt.Errorf("%s: unknown format %q (want text or json)", LogFormatEnv, format)
+	}
+
+	slog.SetDefault(slog.New(handler))
+	return closeLog, nil
+}
+
+/*
+sessionLogger returns a logger that tags every record with the session's ID
+and difficulty, so the turns of concurrent network games can be told apart.
+*/
+func sessionLogger(gameState *GameState) *slog.Logger {
+	return slog.With("session", gameState.SessionID, "difficulty", gameState.Difficulty)
+}
@@ -0,0 +1,917 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Match event types - Every state change of a networked game is published
+// to subscribers as one of these events
+const (
+	EventPlayerJoined = "player_joined" // A player took a seat before the game started
+	EventPlayerLeft   = "player_left"   // A player left before the game started
+	EventGameStarted  = "game_started"  // Turn order is fixed and the clock is running
+	EventTurnStarted  = "turn_started"  // A player is on the clock
+	EventGuess        = "guess"         // A player's guess was evaluated
+	EventTimeout      = "timeout"       // A player ran out of time
+	EventGameOver     = "game_over"     // Someone guessed the target number
+	EventGameAborted  = "game_aborted"  // Everyone left before anyone won
+	EventPlayerAway   = "player_away"   // A player disconnected; their turns are skipped
+	EventPlayerBack   = "player_back"   // A disconnected player reconnected
+	EventChat         = "chat"          // A player said something; never affects the game
+	EventAnnouncement = "announcement"  // The server operator broadcast a message
+)
+
+// listenerBuffer is the number of events buffered per subscriber before a
+// slow subscriber is disconnected to protect the game loop
+const listenerBuffer = 64
+
+// Match errors returned to network clients
+var (
+	ErrMatchFull     = errors.New("game is full")
+	ErrMatchStarted  = errors.New("game has already started")
+	ErrMatchNotReady = errors.New("game has not started yet")
+	ErrMatchOver     = errors.New("game is over")
+	ErrNameTaken     = errors.New("name already taken")
+	ErrInvalidName   = errors.New("name must not be empty")
+	ErrNotYourTurn   = errors.New("it is not your turn")
+	ErrNoPlayers     = errors.New("at least one player is required")
+	ErrNotHost       = errors.New("only the host can do that")
+	ErrMatchAborted  = errors.New("game was abandoned")
+	ErrStaleTurn     = errors.New("guess is for a turn that has already ended")
+	ErrMissingTurn   = errors.New("guess must name the turn it answers")
+)
+
+/*
+MatchEvent describes a single state change in a networked game.
+
+Only the fields relevant to the event type are populated. The target number
+is only ever included in EventGameOver, once it no longer needs protecting.
+*/
+type MatchEvent struct {
+	Type     string         // One of the Event* constants
+	Player   string         // Player the event concerns
+	Players  []string       // Seated players (join/leave/start events)
+	Result   TurnResult     // Guess outcome (guess/timeout events)
+	Deadline time.Time      // When the current turn expires (turn_started)
+	Scores   map[string]int // Final scores (game_over)
+	Target   int            // Revealed target (game_over)
+	Attempts int            // Total attempts so far
+	Low      int            // Smallest number still consistent with every hint
+	High     int            // Largest number still consistent with every hint
+	Turn     int            // Turn number (turn_started)
+	Text     string         // Chat message (chat) or operator message (announcement)
+	Emote    string         // Emote name (chat)
+}
+
+/*
+Match runs one game session whose players are driven by network clients
+rather than the local keyboard.
+
+The match owns its GameState and applies turns through evaluateGuess and
+recordTurn - the same rules used by runGameSession - while enforcing turn
+order and per-turn time limits on the server.
+
+Concurrency Design:
+  - A single mutex guards all state; every public method is safe to call
+    from connection goroutines
+  - Turn timeouts run on time.AfterFunc and are invalidated by a sequence
+    number so a late timer can never skip the wrong turn
+  - Events are fanned out over buffered channels without blocking the game
+*/
+type Match struct {
+	mu       sync.Mutex
+	state    *GameState
+	capacity int
+
+	started  bool
+	finished bool
+	winner   string
+
+	turn      int       // Index into state.Players of the player on the clock
+	turnSeq   int       // Incremented every turn to invalidate stale timers
+	turnCount int       // Turns begun so far; numbers each turn for guesses
+	turnStart time.Time // When the current turn began
+	deadline  time.Time // When the current turn expires
+	timer     *time.Timer
+
+	away map[string]bool // Disconnected players whose turns are skipped
+
+	low, high int // Feasible range implied by the hints given so far
+
+	listeners    map[int]chan MatchEvent
+	feeds        map[int]*eventFeed // Subscriptions that are never dropped
+	nextListener int
+	done         chan struct{}
+}
+
+/*
+newMatch prepares a networked game waiting for players.
+
+Persistent data (leaderboard, history, achievements) is attached so that
+achievements can be evaluated against prior sessions when the game ends.
+
+Parameters:
+- id string: Session ID for structured logs (the room code)
+- difficulty string: Validated difficulty level
+- capacity int: Number of seats (1 to MaxPlayers)
+- timeLimit time.Duration: Time allowed per turn
+- saveData *SaveData: Persistent data for post-game evaluation
+
+Returns:
+- *Match: Match accepting players
+*/
+func newMatch(id, difficulty string, capacity int, timeLimit time.Duration, saveData *SaveData) *Match {
+	return &Match{
+		state: &GameState{
+			SessionID:    id,
+			Difficulty:   difficulty,
+			Target:       generateNumber(difficulty),
+			MaxRange:     getMaxRange(difficulty),
+			TimeLimit:    timeLimit,
+			Scores:       make(map[string]int),
+			Leaderboard:  saveData.Leaderboard,
+			GameHistory:  saveData.GameHistory,
+			Achievements: saveData.Achievements,
+		},
+		capacity:  capacity,
+		low:       1,
+		high:      getMaxRange(difficulty),
+		away:      make(map[string]bool),
+		listeners: make(map[int]chan MatchEvent),
+		feeds:     make(map[int]*eventFeed),
+		done:      make(chan struct{}),
+	}
+}
+
+/*
+Subscribe registers a listener for match events.
+
+Returns:
+  - <-chan MatchEvent: Event stream (closed on unsubscribe, or if the
+    subscriber falls too far behind)
+  - func(): Unsubscribe function, safe to call more than once
+*/
+func (m *Match) Subscribe() (<-chan MatchEvent, func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := m.nextListener
+	m.nextListener++
+	ch := make(chan MatchEvent, listenerBuffer)
+	m.listeners[id] = ch
+
+	return ch, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if ch, exists := m.listeners[id]; exists {
+			delete(m.listeners, id)
+			close(ch)
+		}
+	}
+}
+
+/*
+SubscribeAll registers a listener that is never dropped, however far behind
+it falls: events queue up without bound until read, so neither the game nor
+the listener has to wait for the other. Meant for in-process consumers, such
+as metrics, that must see every event.
+
+Returns:
+- <-chan MatchEvent: Event stream (closed on unsubscribe)
+- func(): Unsubscribe function, safe to call more than once
+*/
+func (m *Match) SubscribeAll() (<-chan MatchEvent, func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := m.nextListener
+	m.nextListener++
+	feed := newEventFeed()
+	m.feeds[id] = feed
+
+	return feed.out, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if feed, exists := m.feeds[id]; exists {
+			delete(m.feeds, id)
+			close(feed.stop)
+		}
+	}
+}
+
+// emit delivers an event to every subscriber; the caller must hold m.mu
+func (m *Match) emit(event MatchEvent) {
+	event.Attempts = m.state.Attempts
+	event.Low, event.High = m.low, m.high
+	for _, feed := range m.feeds {
+		feed.push(event)
+	}
+	for id, ch := range m.listeners {
+		select {
+		case ch <- event:
+		default:
+			// Never block the game on a slow consumer
+			delete(m.listeners, id)
+			close(ch)
+		}
+	}
+}
+
+/*
+Join seats a new player.
+
+Parameters:
+- name string: Requested display name
+
+Returns:
+- error: ErrInvalidName, ErrNameTaken, ErrMatchFull or ErrMatchStarted
+*/
+func (m *Match) Join(name string) error {
+	name = strings.TrimSpace(name)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch {
+	case name == "":
+		return ErrInvalidName
+	case m.started:
+		return ErrMatchStarted
+	case contains(m.state.Players, name):
+		return ErrNameTaken
+	case len(m.state.Players) >= m.capacity:
+		return ErrMatchFull
+	}
+
+	m.state.Players = append(m.state.Players, name)
+	m.emit(MatchEvent{Type: EventPlayerJoined, Player: name, Players: m.playersLocked()})
+	return nil
+}
+
+/*
+Leave gives up a player's seat.
+
+Before the game starts the seat is freed; once it has started the seat is
+kept so turn order stays stable, and the absent player's turns time out.
+*/
+func (m *Match) Leave(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.started {
+		return
+	}
+	for i, player := range m.state.Players {
+		if player == name {
+			m.state.Players = append(m.state.Players[:i], m.state.Players[i+1:]...)
+			m.emit(MatchEvent{Type: EventPlayerLeft, Player: name, Players: m.playersLocked()})
+			return
+		}
+	}
+}
+
+/*
+Start fixes the turn order and puts the first player on the clock.
+
+Returns:
+- error: ErrMatchStarted or ErrNoPlayers
+*/
+func (m *Match) Start() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.started {
+		return ErrMatchStarted
+	}
+	if len(m.state.Players) == 0 {
+		return ErrNoPlayers
+	}
+
+	m.started = true
+	m.state.StartTime = time.Now()
+	logger := sessionLogger(m.state)
+	logger.Info("session started", "mode", "network", "players", m.state.Players,
+		"max_range", m.state.MaxRange, "time_limit", m.state.TimeLimit)
+	logger.Debug("target chosen", "target", m.state.Target)
+	m.emit(MatchEvent{Type: EventGameStarted, Players: m.playersLocked()})
+	m.beginTurnLocked()
+	return nil
+}
+
+/*
+StartBy starts the game on behalf of a player, who must be the host.
+
+The host is the first seated player; if they leave before the start, the
+next player in line takes over.
+
+Returns:
+- error: ErrNotHost, ErrMatchStarted or ErrNoPlayers
+*/
+func (m *Match) StartBy(player string) error {
+	if m.Host() != player {
+		return ErrNotHost
+	}
+	return m.Start()
+}
+
+/*
+Abort ends a game that nobody can win any more, such as when every player
+has disconnected. Aborted games are not recorded.
+*/
+func (m *Match) Abort() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.finished {
+		return
+	}
+	m.finished = true
+	if m.timer != nil {
+		m.timer.Stop()
+	}
+	m.turnSeq++
+	sessionLogger(m.state).Info("session aborted", "started", m.started, "attempts", m.state.Attempts)
+	m.emit(MatchEvent{Type: EventGameAborted})
+	close(m.done)
+}
+
+/*
+SetAway marks a player as disconnected or back.
+
+An away player's turns are skipped as soon as they begin, unless every
+player is away, in which case turns run their normal course so the game
+does not spin.
+*/
+func (m *Match) SetAway(player string, away bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.finished || m.away[player] == away || !contains(m.state.Players, player) {
+		return
+	}
+	if away {
+		m.away[player] = true
+		m.emit(MatchEvent{Type: EventPlayerAway, Player: player})
+	} else {
+		delete(m.away, player)
+		m.emit(MatchEvent{Type: EventPlayerBack, Player: player})
+	}
+
+	// Skip right away if the player disconnected while on the clock
+	if away && m.started && m.state.Players[m.turn] == player && len(m.away) < len(m.state.Players) {
+		m.timer.Stop()
+		seq := m.turnSeq
+		m.timer = time.AfterFunc(0, func() { m.expireTurn(seq) })
+	}
+}
+
+// beginTurnLocked starts the clock for the current player; caller holds m.mu
+func (m *Match) beginTurnLocked() {
+	m.turnSeq++
+	m.turnCount++
+	seq := m.turnSeq
+	player := m.state.Players[m.turn]
+
+	limit := m.state.TimeLimit
+	if m.away[player] && len(m.away) < len(m.state.Players) {
+		limit = 0 // Skip disconnected players without stalling everyone else
+	}
+	m.turnStart = time.Now()
+	m.deadline = m.turnStart.Add(limit)
+	m.timer = time.AfterFunc(limit, func() { m.expireTurn(seq) })
+
+	m.emit(MatchEvent{
+		Type:     EventTurnStarted,
+		Player:   player,
+		Deadline: m.deadline,
+		Turn:     m.turnCount,
+	})
+}
+
+// expireTurn skips the turn identified by seq if it is still in progress
+func (m *Match) expireTurn(seq int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.finished || seq != m.turnSeq {
+		return // The player answered in time, or the game ended
+	}
+
+	player := m.state.Players[m.turn]
+	result := TurnResult{Valid: false, TimedOut: true, Hint: "Timeout - turn skipped"}
+	if m.away[player] {
+		result.Hint = "Disconnected - turn skipped"
+	}
+	recordTurn(m.state, player, result, time.Since(m.turnStart))
+	m.emit(MatchEvent{Type: EventTimeout, Player: player, Result: result})
+	m.advanceLocked()
+}
+
+/*
+SubmitGuess applies a guess from a player.
+
+The guess is validated with evaluateGuess and recorded with recordTurn, so
+invalid input consumes the turn exactly as it does in the local game.
+
+Every guess names the turn it answers (the number from EventTurnStarted).
+Only one guess per turn is accepted: a second guess naming the same turn is
+rejected with ErrStaleTurn even when the player is on the clock again, as
+in a solo game, so guesses queued up ahead of their turns are refused.
+
+Parameters:
+- player string: Player submitting the guess
+- text string: Raw guess text
+- turn int: Turn the guess answers
+
+Returns:
+  - TurnResult: Outcome of the guess
+  - error: ErrMissingTurn, ErrMatchNotReady, ErrMatchOver, ErrNotYourTurn or
+    ErrStaleTurn
+*/
+func (m *Match) SubmitGuess(player, text string, turn int) (TurnResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch {
+	case turn < 1:
+		return TurnResult{}, ErrMissingTurn
+	case m.finished:
+		return TurnResult{}, ErrMatchOver
+	case !m.started:
+		return TurnResult{}, ErrMatchNotReady
+	case m.state.Players[m.turn] != player:
+		return TurnResult{}, ErrNotYourTurn
+	case turn != m.turnCount:
+		return TurnResult{}, ErrStaleTurn
+	}
+
+	m.timer.Stop()
+	m.turnSeq++ // Invalidate the timer even if it already fired
+
+	result := evaluateGuess(m.state, text)
+	won := recordTurn(m.state, player, result, time.Since(m.turnStart))
+	m.narrowRangeLocked(result)
+	m.emit(MatchEvent{Type: EventGuess, Player: player, Result: result})
+
+	if won {
+		m.finishLocked(player)
+	} else {
+		m.advanceLocked()
+	}
+	return result, nil
+}
+
+// narrowRangeLocked applies a guess's hint to the feasible range; caller holds m.mu
+func (m *Match) narrowRangeLocked(result TurnResult) {
+	m.low, m.high = narrowRange(m.low, m.high, m.state.Target, result)
+}
+
+// advanceLocked moves the clock to the next player; caller holds m.mu
+func (m *Match) advanceLocked() {
+	m.turn = (m.turn + 1) % len(m.state.Players)
+	m.beginTurnLocked()
+}
+
+// finishLocked ends the game with a winner; caller holds m.mu
+func (m *Match) finishLocked(winner string) {
+	m.finished = true
+	m.winner = winner
+	m.state.NewAchievements = evaluateAchievements(m.state)
+	sessionLogger(m.state).Info("session finished", "winner", winner, "attempts", m.state.Attempts,
+		"duration", m.state.EndTime.Sub(m.state.StartTime), "scores", m.state.Scores)
+
+	scores := make(map[string]int, len(m.state.Scores))
+	for player, score := range m.state.Scores {
+		scores[player] = score
+	}
+	m.emit(MatchEvent{Type: EventGameOver, Player: winner, Scores: scores, Target: m.state.Target})
+	close(m.done)
+}
+
+/*
+Chat relays a chat message or emote to every subscriber.
+
+Chat travels through the same event stream as the game so that players and
+spectators see it in order with the guesses, but it never touches the game
+state. Validation and rate limiting happen in Room.Chat.
+
+Returns:
+- error: ErrMatchOver once the game has ended
+*/
+func (m *Match) Chat(player, text, emote string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.finished {
+		return ErrMatchOver
+	}
+	m.emit(MatchEvent{Type: EventChat, Player: player, Text: text, Emote: emote})
+	return nil
+}
+
+/*
+Announce relays a message from the server operator to every subscriber.
+
+Returns:
+- error: ErrMatchOver once the game has ended
+*/
+func (m *Match) Announce(text string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.finished {
+		return ErrMatchOver
+	}
+	m.emit(MatchEvent{Type: EventAnnouncement, Text: text})
+	return nil
+}
+
+/*
+SetTimeLimit changes the time allowed per turn. The turn in progress keeps
+its deadline; the new limit applies from the next turn.
+
+Returns:
+- error: ErrMatchOver once the game has ended
+*/
+func (m *Match) SetTimeLimit(limit time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.finished {
+		return ErrMatchOver
+	}
+	sessionLogger(m.state).Info("time limit changed", "from", m.state.TimeLimit, "to", limit)
+	m.state.TimeLimit = limit
+	return nil
+}
+
+/*
+Done returns a channel that is closed when the game has a winner or is
+aborted.
+*/
+func (m *Match) Done() <-chan struct{} {
+	return m.done
+}
+
+/*
+FinishedState returns the completed game state for persistence.
+
+Returns:
+  - *GameState: The final state (must not be modified)
+  - error: ErrMatchNotReady if the game is still in progress, or
+    ErrMatchAborted if it ended without a winner
+*/
+func (m *Match) FinishedState() (*GameState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.finished {
+		return nil, ErrMatchNotReady
+	}
+	if m.winner == "" {
+		return nil, ErrMatchAborted
+	}
+	return m.state, nil
+}
+
+/*
+Summary describes the match configuration for welcome messages.
+
+Returns:
+- string: e.g. "Medium (1-100), 2/4 players, 10s per guess"
+*/
+func (m *Match) Summary() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return fmt.Sprintf("%s (1-%d), %d/%d players, %s per guess",
+		strings.Title(m.state.Difficulty), m.state.MaxRange,
+		len(m.state.Players), m.capacity, m.state.TimeLimit)
+}
+
+/*
+Info returns the fixed configuration values clients need.
+
+Returns:
+- difficulty string, maxRange int, timeLimit time.Duration, capacity int
+*/
+func (m *Match) Info() (string, int, time.Duration, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state.Difficulty, m.state.MaxRange, m.state.TimeLimit, m.capacity
+}
+
+/*
+Players returns a copy of the seated players in turn order.
+*/
+func (m *Match) Players() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.playersLocked()
+}
+
+// playersLocked copies the player list; caller holds m.mu
+func (m *Match) playersLocked() []string {
+	return append([]string(nil), m.state.Players...)
+}
+
+/*
+Host returns the player allowed to start the game, or "" if nobody is
+seated.
+*/
+func (m *Match) Host() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return firstOrEmpty(m.state.Players)
+}
+
+// firstOrEmpty returns the first element of a slice, or "" if it is empty
+func firstOrEmpty(items []string) string {
+	if len(items) == 0 {
+		return ""
+	}
+	return items[0]
+}
+
+/*
+IsFull reports whether every seat is taken.
+*/
+func (m *Match) IsFull() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.state.Players) >= m.capacity
+}
+
+/*
+MatchSnapshot is a point-in-time view of a match that is safe to show to
+any player.
+
+The target number is only included once the game is over.
+*/
+type MatchSnapshot struct {
+	Difficulty    string         `json:"difficulty"`
+	MaxRange      int            `json:"max_range"`
+	TimeLimitMS   int64          `json:"time_limit_ms"`
+	Capacity      int            `json:"capacity"`
+	Players       []string       `json:"players"`
+	Host          string         `json:"host,omitempty"` // Player allowed to start the game
+	Away          []string       `json:"away,omitempty"` // Disconnected players whose turns are skipped
+	Started       bool           `json:"started"`
+	Finished      bool           `json:"finished"`
+	CurrentPlayer string         `json:"current_player,omitempty"` // Player on the clock
+	Turn          int            `json:"turn,omitempty"`           // Number of the current turn
+	TimeLeftMS    int64          `json:"time_left_ms,omitempty"`   // Time remaining in the current turn
+	Attempts      int            `json:"attempts"`
+	Low           int            `json:"low"`  // Feasible range implied by the hints
+	High          int            `json:"high"` // so far; narrows with every guess
+	Guesses       []GuessView    `json:"guesses"`
+	Scores        map[string]int `json:"scores,omitempty"`
+	Winner        string         `json:"winner,omitempty"`
+	Target        int            `json:"target,omitempty"`
+}
+
+/*
+GuessView is the public form of a GuessRecord.
+*/
+type GuessView struct {
+	Player   string `json:"player"`
+	Value    int    `json:"value,omitempty"`
+	Valid    bool   `json:"valid"`
+	Correct  bool   `json:"correct"`
+	TimedOut bool   `json:"timed_out,omitempty"`
+	Hint     string `json:"hint"`
+}
+
+// guessView converts a guess log entry to its public form
+func guessView(record GuessRecord) GuessView {
+	return GuessView{
+		Player:   record.Player,
+		Value:    record.Result.Value,
+		Valid:    record.Result.Valid,
+		Correct:  record.Result.Correct,
+		TimedOut: record.Result.TimedOut,
+		Hint:     record.Result.Hint,
+	}
+}
+
+/*
+Target returns the secret number.
+
+Only spectator views whose host enabled SpectatorsSeeTarget may show it
+before the game ends; it must never be sent to a seated player.
+*/
+func (m *Match) Target() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state.Target
+}
+
+/*
+DumpedGuess is a guess log entry as shown in a MatchDump, with its timing.
+*/
+type DumpedGuess struct {
+	GuessView
+	Elapsed      string `json:"elapsed"`       // Time since the game started when the turn ended
+	TurnDuration string `json:"turn_duration"` // Time the player spent on the turn
+}
+
+/*
+MatchDump is the complete state of a match, target included, for operators
+debugging a room. It must never be shown to players.
+*/
+type MatchDump struct {
+	Difficulty string         `json:"difficulty"`
+	Target     int            `json:"target"`
+	MaxRange   int            `json:"max_range"`
+	TimeLimit  string         `json:"time_limit"`
+	Players    []string       `json:"players"`
+	Scores     map[string]int `json:"scores"`
+	StartTime  time.Time      `json:"start_time"`
+	EndTime    time.Time      `json:"end_time"`
+	Attempts   int            `json:"attempts"`
+	GuessLog   []DumpedGuess  `json:"guess_log"`
+
+	Capacity    int             `json:"capacity"`
+	Started     bool            `json:"started"`
+	Finished    bool            `json:"finished"`
+	Winner      string          `json:"winner"`
+	TurnIndex   int             `json:"turn_index"`
+	TurnCount   int             `json:"turn_count"`
+	TurnStart   time.Time       `json:"turn_start"`
+	Deadline    time.Time       `json:"deadline"`
+	Away        map[string]bool `json:"away"`
+	Low         int             `json:"low"`
+	High        int             `json:"high"`
+	Subscribers int             `json:"subscribers"`
+
+	KnownPlayers int `json:"known_players"` // Leaderboard entries loaded for achievements
+	PastSessions int `json:"past_sessions"` // History entries loaded for achievements
+}
+
+/*
+Dump copies the match's complete state for debugging.
+
+Returns:
+- MatchDump: Deep copy safe to encode while the game continues
+*/
+func (m *Match) Dump() MatchDump {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	dump := MatchDump{
+		Difficulty:   m.state.Difficulty,
+		Target:       m.state.Target,
+		MaxRange:     m.state.MaxRange,
+		TimeLimit:    m.state.TimeLimit.String(),
+		Players:      append([]string{}, m.state.Players...),
+		Scores:       make(map[string]int, len(m.state.Scores)),
+		StartTime:    m.state.StartTime,
+		EndTime:      m.state.EndTime,
+		Attempts:     m.state.Attempts,
+		GuessLog:     make([]DumpedGuess, 0, len(m.state.GuessLog)),
+		Capacity:     m.capacity,
+		Started:      m.started,
+		Finished:     m.finished,
+		Winner:       m.winner,
+		TurnIndex:    m.turn,
+		TurnCount:    m.turnCount,
+		TurnStart:    m.turnStart,
+		Deadline:     m.deadline,
+		Away:         make(map[string]bool, len(m.away)),
+		Low:          m.low,
+		High:         m.high,
+		Subscribers:  len(m.listeners),
+		KnownPlayers: len(m.state.Leaderboard),
+		PastSessions: len(m.state.GameHistory),
+	}
+	for player, score := range m.state.Scores {
+		dump.Scores[player] = score
+	}
+	for _, record := range m.state.GuessLog {
+		dump.GuessLog = append(dump.GuessLog, DumpedGuess{
+			GuessView:    guessView(record),
+			Elapsed:      record.Elapsed.String(),
+			TurnDuration: record.TurnDuration.String(),
+		})
+	}
+	for player, away := range m.away {
+		dump.Away[player] = away
+	}
+	return dump
+}
+
+/*
+Snapshot captures the current state of the match.
+
+Returns:
+- MatchSnapshot: Copy of the public game state
+*/
+func (m *Match) Snapshot() MatchSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := MatchSnapshot{
+		Difficulty:  m.state.Difficulty,
+		MaxRange:    m.state.MaxRange,
+		TimeLimitMS: m.state.TimeLimit.Milliseconds(),
+		Capacity:    m.capacity,
+		Players:     append([]string{}, m.state.Players...),
+		Host:        firstOrEmpty(m.state.Players),
+		Started:     m.started,
+		Finished:    m.finished,
+		Attempts:    m.state.Attempts,
+		Low:         m.low,
+		High:        m.high,
+		Guesses:     make([]GuessView, 0, len(m.state.GuessLog)),
+	}
+	for _, player := range m.state.Players {
+		if m.away[player] {
+			snapshot.Away = append(snapshot.Away, player)
+		}
+	}
+	for _, record := range m.state.GuessLog {
+		snapshot.Guesses = append(snapshot.Guesses, guessView(record))
+	}
+
+	switch {
+	case m.finished:
+		snapshot.Winner = m.winner
+		snapshot.Target = m.state.Target
+		snapshot.Scores = make(map[string]int, len(m.state.Scores))
+		for player, score := range m.state.Scores {
+			snapshot.Scores[player] = score
+		}
+	case m.started:
+		snapshot.CurrentPlayer = m.state.Players[m.turn]
+		snapshot.Turn = m.turnCount
+		snapshot.TimeLeftMS = time.Until(m.deadline).Milliseconds()
+	}
+	return snapshot
+}
+
+/*
+eventFeed is an unbounded event queue behind a SubscribeAll channel. A
+pump goroutine hands queued events to the reader one at a time.
+*/
+type eventFeed struct {
+	mu    sync.Mutex
+	queue []MatchEvent
+
+	wake chan struct{} // Signals the pump that events were queued
+	stop chan struct{} // Closed on unsubscribe
+	out  chan MatchEvent
+}
+
+// newEventFeed creates a feed and starts its pump
+func newEventFeed() *eventFeed {
+	feed := &eventFeed{
+		wake: make(chan struct{}, 1),
+		stop: make(chan struct{}),
+		out:  make(chan MatchEvent),
+	}
+	go feed.pump()
+	return feed
+}
+
+// push queues an event without blocking
+func (f *eventFeed) push(event MatchEvent) {
+	f.mu.Lock()
+	f.queue = append(f.queue, event)
+	f.mu.Unlock()
+
+	select {
+	case f.wake <- struct{}{}:
+	default: // The pump has a wake-up pending already
+	}
+}
+
+// pump delivers queued events in order until the feed is stopped
+func (f *eventFeed) pump() {
+	defer close(f.out)
+	for {
+		f.mu.Lock()
+		if len(f.queue) == 0 {
+			f.mu.Unlock()
+			select {
+			case <-f.wake:
+				continue
+			case <-f.stop:
+				return
+			}
+		}
+		event := f.queue[0]
+		f.queue = f.queue[1:]
+		f.mu.Unlock()
+
+		select {
+		case f.out <- event:
+		case <-f.stop:
+			return
+		}
+	}
+}
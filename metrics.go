@@ -0,0 +1,260 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TurnLatencyBuckets are the upper bounds, in seconds, of the turn latency
+// histogram - fine-grained around typical thinking times, up to a minute
+var TurnLatencyBuckets = []float64{0.5, 1, 2, 3, 5, 7.5, 10, 15, 20, 30, 60}
+
+/*
+serverMetrics counts what happens in a lobby's rooms for monitoring.
+
+Counters are fed by a subscription to every room's match; gauges such as
+active rooms and connected players are read from the lobby when scraped.
+The output is the Prometheus text exposition format, written by hand so the
+server needs no dependencies.
+*/
+type serverMetrics struct {
+	mu            sync.Mutex
+	gamesStarted  map[string]int    // Difficulty -> games started
+	gamesFinished map[[2]string]int // {difficulty, outcome} -> games finished
+	guesses       map[string]int    // Result (correct, wrong, invalid) -> guesses
+	timeouts      int
+
+	latencyBuckets []int // Cumulative counts per TurnLatencyBuckets entry
+	latencySum     float64
+	latencyCount   int
+}
+
+/*
+newServerMetrics creates metrics with every counter at zero.
+*/
+func newServerMetrics() *serverMetrics {
+	m := &serverMetrics{
+		gamesStarted:   make(map[string]int),
+		gamesFinished:  make(map[[2]string]int),
+		guesses:        map[string]int{"correct": 0, "wrong": 0, "invalid": 0},
+		latencyBuckets: make([]int, len(TurnLatencyBuckets)),
+	}
+	// Export every series from the start so rates work from the first scrape
+	for _, difficulty := range Difficulties {
+		m.gamesStarted[difficulty] = 0
+		m.gamesFinished[[2]string{difficulty, "won"}] = 0
+		m.gamesFinished[[2]string{difficulty, "aborted"}] = 0
+		m.gamesFinished[[2]string{difficulty, "expired"}] = 0
+	}
+	return m
+}
+
+/*
+observe counts one room's match events until its game ends.
+
+Turn latency is the time from a turn starting to the guess that ends it;
+turns that time out are counted as timeouts instead. A game abandoned after
+it started finishes as "aborted"; a room closed before its game started -
+pruned while idle or emptied by its players - finishes as "expired".
+
+Parameters:
+- difficulty string: The room's difficulty, used as a label
+- events <-chan MatchEvent: Subscription to the room's match
+*/
+func (m *serverMetrics) observe(difficulty string, events <-chan MatchEvent) {
+	var turnStart time.Time
+	started := false
+	for event := range events {
+		m.mu.Lock()
+		switch event.Type {
+		case EventGameStarted:
+			m.gamesStarted[difficulty]++
+			started = true
+		case EventTurnStarted:
+			turnStart = time.Now()
+		case EventGuess:
+			switch {
+			case event.Result.Correct:
+				m.guesses["correct"]++
+			case event.Result.Valid:
+				m.guesses["wrong"]++
+			default:
+				m.guesses["invalid"]++
+			}
+			if !turnStart.IsZero() {
+				m.observeLatencyLocked(time.Since(turnStart).Seconds())
+				turnStart = time.Time{}
+			}
+		case EventTimeout:
+			m.timeouts++
+			turnStart = time.Time{}
+		case EventGameOver:
+			m.gamesFinished[[2]string{difficulty, "won"}]++
+		case EventGameAborted:
+			if started {
+				m.gamesFinished[[2]string{difficulty, "aborted"}]++
+			} else {
+				m.gamesFinished[[2]string{difficulty, "expired"}]++
+			}
+		}
+		m.mu.Unlock()
+
+		if event.Type == EventGameOver || event.Type == EventGameAborted {
+			return
+		}
+	}
+}
+
+// observeLatencyLocked adds one turn to the latency histogram; caller holds m.mu
+func (m *serverMetrics) observeLatencyLocked(seconds float64) {
+	for i, bound := range TurnLatencyBuckets {
+		if seconds <= bound {
+			m.latencyBuckets[i]++
+		}
+	}
+	m.latencySum += seconds
+	m.latencyCount++
+}
+
+/*
+write renders every metric in the Prometheus text format.
+
+Parameters:
+- w io.Writer: Destination
+- lobby *Lobby: Lobby whose rooms and players the gauges describe
+*/
+func (m *serverMetrics) write(w io.Writer, lobby *Lobby) {
+	waiting, playing, connected, away, spectators := 0, 0, 0, 0, 0
+	for _, room := range lobby.Rooms() {
+		snapshot := room.Match.Snapshot()
+		if snapshot.Finished {
+			continue
+		}
+		if snapshot.Started {
+			playing++
+		} else {
+			waiting++
+		}
+		info := room.Info()
+		connected += info.Players - len(snapshot.Away)
+		away += len(snapshot.Away)
+		spectators += info.Spectators
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	writeMetricHeader(w, "guessing_game_games_started_total", "counter", "Games started, by difficulty.")
+	for _, difficulty := range sortedKeys(m.gamesStarted) {
+		fmt.Fprintf(w, "guessing_game_games_started_total{difficulty=%q} %d\n", difficulty, m.gamesStarted[difficulty])
+	}
+
+	writeMetricHeader(w, "guessing_game_games_finished_total", "counter", "Games finished, by difficulty and outcome (won, aborted, or expired before starting).")
+	finished := make([][2]string, 0, len(m.gamesFinished))
+	for labels := range m.gamesFinished {
+		finished = append(finished, labels)
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i][0]+finished[i][1] < finished[j][0]+finished[j][1]
+	})
+	for _, labels := range finished {
+		fmt.Fprintf(w, "guessing_game_games_finished_total{difficulty=%q,outcome=%q} %d\n", labels[0], labels[1], m.gamesFinished[labels])
+	}
+
+	writeMetricHeader(w, "guessing_game_guesses_total", "counter", "Guesses submitted, by result (correct, wrong or invalid).")
+	for _, result := range sortedKeys(m.guesses) {
+		fmt.Fprintf(w, "guessing_game_guesses_total{result=%q} %d\n", result, m.guesses[result])
+	}
+
+	writeMetricHeader(w, "guessing_game_timeouts_total", "counter", "Turns skipped because the player ran out of time.")
+	fmt.Fprintf(w, "guessing_game_timeouts_total %d\n", m.timeouts)
+
+	writeMetricHeader(w, "guessing_game_turn_latency_seconds", "histogram", "Time from a turn starting to the player's guess.")
+	for i, bound := range TurnLatencyBuckets {
+		fmt.Fprintf(w, "guessing_game_turn_latency_seconds_bucket{le=\"%g\"} %d\n", bound, m.latencyBuckets[i])
+	}
+	fmt.Fprintf(w, "guessing_game_turn_latency_seconds_bucket{le=\"+Inf\"} %d\n", m.latencyCount)
+	fmt.Fprintf(w, "guessing_game_turn_latency_seconds_sum %g\n", m.latencySum)
+	fmt.Fprintf(w, "guessing_game_turn_latency_seconds_count %d\n", m.latencyCount)
+
+	writeMetricHeader(w, "guessing_game_active_rooms", "gauge", "Rooms whose game has not finished, by state (waiting or playing).")
+	fmt.Fprintf(w, "guessing_game_active_rooms{state=\"playing\"} %d\n", playing)
+	fmt.Fprintf(w, "guessing_game_active_rooms{state=\"waiting\"} %d\n", waiting)
+
+	writeMetricHeader(w, "guessing_game_players", "gauge", "Players seated in active rooms, by state (connected or away).")
+	fmt.Fprintf(w, "guessing_game_players{state=\"away\"} %d\n", away)
+	fmt.Fprintf(w, "guessing_game_players{state=\"connected\"} %d\n", connected)
+
+	writeMetricHeader(w, "guessing_game_spectators", "gauge", "Open spectator views of active rooms.")
+	fmt.Fprintf(w, "guessing_game_spectators %d\n", spectators)
+}
+
+/*
+writeMetricHeader writes the HELP and TYPE lines introducing a metric.
+*/
+func writeMetricHeader(w io.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+/*
+sortedKeys returns a counter map's labels in a stable order.
+*/
+func sortedKeys(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+/*
+metricsHandler serves a lobby's metrics at /metrics.
+*/
+func metricsHandler(lobby *Lobby) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		lobby.metrics.write(w, lobby)
+	})
+	return mux
+}
+
+/*
+startMetricsServer serves /metrics for a lobby in the background.
+
+Metrics get their own listener so they can be scraped from a monitoring
+network without exposing the game API there, or vice versa.
+
+Parameters:
+- addr string: Listen address; empty disables the metrics endpoint
+- lobby *Lobby: Lobby to report on
+
+Returns:
+- func(): Shuts the metrics server down
+- error: Failure to listen on addr
+*/
+func startMetricsServer(addr string, lobby *Lobby) (func(), error) {
+	if addr == "" {
+		return func() {}, nil
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           metricsHandler(lobby),
+		ReadHeaderTimeout: JoinTimeout,
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	go server.Serve(listener)
+
+	fmt.Printf("%sMetrics:%s http://%s/metrics\n", ColorBlue, ColorReset, listener.Addr())
+	return func() { server.Close() }, nil
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// Persistence constants - The save file location can be overridden so that
+// several independent game installations can share one machine
+const (
+	DataFileEnv     = "GUESSING_GAME_DATA"  // Environment variable overriding the save file path
+	DefaultDataFile = ".guessing-game.json" // Save file name inside the user's home directory
+	SaveDataVersion = 1                     // Schema version written into every save file
+)
+
+/*
+SaveData is the on-disk representation of all cross-session game data.
+
+The structure mirrors the persistent fields of GameState so that a saved file
+can be loaded straight back into the main application loop.
+
+Compatibility Strategy:
+- A schema version is written with every save for future migrations
+- Missing collections are normalized to empty values on load
+- Unknown fields are ignored so older binaries can read newer files
+*/
+type SaveData struct {
+	Version     int            `json:"version"`
+	Leaderboard map[string]int `json:"leaderboard"`
+	GameHistory []GameSession  `json:"game_history"`
+
+	Achievements map[string][]UnlockedAchievement `json:"achievements"`
+}
+
+/*
+newSaveData creates an empty save with all collections initialized.
+
+Returns:
+- *SaveData: Ready-to-use save data with the current schema version
+*/
+func newSaveData() *SaveData {
+	return &SaveData{
+		Version:      SaveDataVersion,
+		Leaderboard:  make(map[string]int),
+		Achievements: make(map[string][]UnlockedAchievement),
+	}
+}
+
+/*
+dataFilePath resolves the location of the save file.
+
+Resolution Order:
+1. The GUESSING_GAME_DATA environment variable, when set
+2. DefaultDataFile inside the user's home directory
+3. DefaultDataFile in the current working directory as a last resort
+
+Returns:
+- string: Path of the save file (which may not exist yet)
+*/
+func dataFilePath() string {
+	if path := os.Getenv(DataFileEnv); path != "" {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return DefaultDataFile
+	}
+	return filepath.Join(home, DefaultDataFile)
+}
+
+/*
+loadSaveData reads persistent game data from disk.
+
+A missing save file is not an error - it simply means no games have been
+played yet, so an empty save is returned.
+
+Parameters:
+- path string: Location of the save file
+
+Returns:
+- *SaveData: Loaded (or freshly initialized) save data
+- error: Read or decode failure for an existing file
+*/
+func loadSaveData(path string) (data *SaveData, err error) {
+	defer func() {
+		if err != nil {
+			slog.Error("loading save file failed", "path", path, "err", err)
+		}
+	}()
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		slog.Debug("no save file yet", "path", path)
+		return newSaveData(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading save file: %w", err)
+	}
+
+	data = newSaveData()
+	if err := json.Unmarshal(raw, data); err != nil {
+		return nil, fmt.Errorf("decoding save file %s: %w", path, err)
+	}
+
+	// Normalize collections that may be absent from older or hand-edited files
+	if data.Leaderboard == nil {
+		data.Leaderboard = make(map[string]int)
+	}
+	if data.Achievements == nil {
+		data.Achievements = make(map[string][]UnlockedAchievement)
+	}
+
+	slog.Debug("save file loaded", "path", path, "sessions", len(data.GameHistory), "players", len(data.Leaderboard))
+	return data, nil
+}
+
+/*
+saveSaveData writes persistent game data to disk.
+
+The data is first written to a temporary file in the same directory and then
+renamed over the previous save, so an interrupted write never leaves a
+truncated save file behind.
+
+Parameters:
+- path string: Location of the save file
+- data *SaveData: Data to persist
+
+Returns:
+- error: Encode, write or rename failure
+*/
+func saveSaveData(path string, data *SaveData) (err error) {
+	defer func() {
+		if err != nil {
+			slog.Error("writing save file failed", "path", path, "err", err)
+		}
+	}()
+
+	data.Version = SaveDataVersion
+
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding save data: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating temporary save file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op after a successful rename
+
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing save file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing save file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("replacing save file: %w", err)
+	}
+	slog.Info("save file written", "path", path, "bytes", len(raw),
+		"sessions", len(data.GameHistory), "players", len(data.Leaderboard))
+	return nil
+}
+
+/*
+recordFinishedGame merges a finished game into the save file.
+
+Used by non-interactive front ends such as the network server. The save is
+re-read immediately before merging so results recorded by other processes
+since the game began are preserved.
+
+Parameters:
+- path string: Location of the save file
+- gameState *GameState: Completed game
+
+Returns:
+- error: Load or save failure
+*/
+func recordFinishedGame(path string, gameState *GameState) error {
+	data, err := loadSaveData(path)
+	if err != nil {
+		return err
+	}
+
+	updatePersistentData(gameState, &data.Leaderboard, &data.GameHistory, data.Achievements)
+	sessionLogger(gameState).Info("recording finished game", "path", path, "scores", gameState.Scores)
+	return saveSaveData(path, data)
+}
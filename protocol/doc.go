@@ -0,0 +1,145 @@
+/*
+Package protocol defines the wire format spoken between the number guessing
+game server ("guessing-game serve") and its clients.
+
+Transport:
+The protocol runs over a plain TCP connection. Every message is a single JSON
+object terminated by a newline ("JSON lines"). Lines longer than
+MaxMessageSize bytes are rejected. Fields that do not apply to a message type
+are omitted, and unknown fields must be ignored so that new optional fields
+can be added without a version bump.
+
+Discovery:
+Servers may announce themselves on the local network by broadcasting an
+Announcement as a single JSON datagram to UDP port DiscoveryPort every few
+seconds. It names the server, the TCP port to connect to and how many rooms
+have free seats, listing as many as fit in MaxAnnouncement bytes, at most
+MaxAnnouncedRooms, so the datagram is never fragmented; the rest are read over TCP with list_rooms. The server's
+IP address is the datagram's source address.
+
+Versioning:
+The current protocol version is Version. Clients announce the version they
+speak in the "v" field of their join message; the server answers with its own
+version in the welcome message. A server rejects a join whose version it does
+not support with an error message carrying CodeUnsupportedVersion. A join
+without "v" is treated as the current version so the protocol stays usable
+from tools like netcat. Version 2 made "turn" mandatory in guess messages,
+so version 1 clients are refused rather than having their guesses rejected.
+
+Rooms:
+A server hosts many rooms, each identified by a short invite code. Before
+joining, a client may send list_rooms any number of times to read the lobby.
+It then either creates a room (becoming its host) or joins one by code; a
+join without "room" joins the oldest open room. The host is always the first
+player in the turn order and may start the game before every seat is taken;
+otherwise the game starts when the room is full. When every player has left,
+the room closes and a game in progress is aborted.
+
+Reconnection:
+The welcome message carries a session token. If the connection drops after
+the game has started, the seat is held for a grace period chosen by the
+server; meanwhile the player's turns are skipped and the other players
+receive player_away. Sending resume with the room code and token within the
+grace period reclaims the seat: the server answers with welcome, a history
+message listing every turn so far, and the turn in progress. After the grace
+period the token is rejected with CodeInvalidSession.
+
+Spectators:
+Sending watch with a room code instead of join makes the connection a
+read-only spectator. Its welcome has "spectator":true and is followed by a
+history message. Spectators receive every guess as a "guess" announcement;
+guess, timeout and history messages carry the feasible range low-high that
+the hints leave. If the host allowed it (spectators_see_target on create, or
+a settings message), the welcome, guess and timeout messages sent to
+spectators also carry the target. Players may not spectate their own room:
+a watch carrying a seated player's token is rejected with
+CodeSeatedSpectator, as is one from a seated player's IP address while the
+target is revealed, and the target is never sent to such an address. Anything a spectator sends is rejected, guesses with CodeReadOnly.
+
+Chat:
+Seated players may send chat messages (text of up to 200 characters) or
+quick emotes at any time before the game ends; chat is relayed to every
+player and spectator in the room as a chat message and is never treated as
+a guess. Senders who exceed the chat rate limit receive CodeRateLimited.
+Sending mute with a player name stops delivery of that player's chat to
+this connection only; unmute restores it.
+
+Fair Play:
+The server is authoritative: it alone knows the target, decides whose turn
+it is and validates every guess. Each turn_start carries a turn number, and
+every guess must name the turn it answers in "turn" (a guess without one is
+rejected with CodeMalformed); the server accepts at most one guess per
+turn, so a guess for a turn that has already ended (or a second guess for
+the same turn) is rejected with CodeStaleTurn. Every
+connection is rate limited (CodeRateLimited); connections that keep sending
+refused messages - floods, malformed lines, guesses out of turn - are
+logged and eventually disconnected.
+
+Server Operator:
+The server operator may broadcast announcements to a room (delivered as an
+announcement message with "text"), change its time limit, end its game
+(game_aborted) or remove a player. A removed player's connection is closed
+and resuming their session fails with CodeKicked.
+
+Session Flow:
+
+	client                          server
+	  | --- list_rooms ---------------> |  (optional)
+	  | <-------- rooms {rooms}         |
+	  | --- join {name, v, room} -----> |  or create {name, v, difficulty, ...}
+	  | <-------- welcome {room,...}    |  (or error, then close)
+	  | <-------- player_joined ...     |
+	  | --- start --------------------> |  (host only, optional)
+	  | <-------- game_started          |  when full or started by the host
+	  | <-------- turn_start            |  your_turn=true on your turn
+	  | --- guess {guess} ------------> |
+	  | <-------- hint                  |  result of your own guess
+	  | <-------- guess                 |  another player's guess
+	  | <-------- timeout               |  someone ran out of time
+	  | <-------- win                   |  the winning guess and score
+	  | <-------- game_over             |  target, scores; server closes
+	  | <-------- game_aborted          |  instead, if everyone else left
+
+Client Messages:
+
+	list_rooms  {"type":"list_rooms","v":2}
+	join        {"type":"join","v":2,"name":"Alice","room":"K7QX2M"}
+	create      {"type":"create","v":2,"name":"Alice","difficulty":"hard","capacity":3,"time_limit_ms":15000}
+	resume      {"type":"resume","v":2,"room":"K7QX2M","token":"<token from welcome>"}
+	watch       {"type":"watch","v":2,"room":"K7QX2M"}   (a token here marks a seated player and is refused)
+	start       {"type":"start"}
+	settings    {"type":"settings","spectators_see_target":true}
+	chat        {"type":"chat","text":"good luck!"}  or  {"type":"chat","emote":"gg"}
+	mute        {"type":"mute","player":"Bob"}
+	unmute      {"type":"unmute","player":"Bob"}
+	guess       {"type":"guess","guess":"42","turn":7}
+
+Server Messages:
+
+	rooms          rooms (code, host, difficulty, max_range, time_limit_ms, players, capacity, started, spectators, spectators_see_target)
+	welcome        room, host, token, name, players, capacity, difficulty, max_range, time_limit_ms, v
+	               (spectators: spectator, low, high, target instead of token and name)
+	history        players, history (player, value, valid, correct, timed_out, hint), attempts, low, high
+	settings       room, spectators_see_target (confirms the host's change)
+	player_joined  player, players, host
+	player_left    player, players, host
+	player_away    player
+	player_back    player
+	game_started   players
+	turn_start     player, your_turn, turn, time_left_ms, attempts
+	hint           player, value, valid, correct, hint, attempts, low, high
+	guess          player, value, valid, correct, hint, attempts, low, high (target for spectators)
+	timeout        player, hint, attempts, low, high (target for spectators)
+	win            player, value, score, attempts
+	game_over      winner, target, scores, attempts
+	game_aborted   attempts
+	chat           player, text or emote
+	announcement   text (from the server operator)
+	error          code, message
+
+Errors:
+Error messages do not end the session unless they answer a join. The "code"
+field is one of the Code* constants and is stable across releases; "message"
+is human-readable and may change.
+*/
+package protocol
@@ -0,0 +1,342 @@
+package protocol
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Protocol constants
+const (
+	Version           = 2    // Current protocol version; 2 made "turn" mandatory in guesses
+	MaxMessageSize    = 4096 // Longest accepted message line in bytes
+	DiscoveryPort     = 7778 // UDP port LAN announcements are broadcast to
+	MaxAnnouncedRooms = 5    // Most rooms listed in one announcement
+	MaxAnnouncement   = 1400 // Longest announcement in bytes, so it is never fragmented
+)
+
+// Client-to-server message types
+const (
+	TypeJoin      = "join"
+	TypeCreate    = "create"
+	TypeResume    = "resume"
+	TypeWatch     = "watch"
+	TypeListRooms = "list_rooms"
+	TypeStart     = "start"
+	TypeSettings  = "settings" // Host changes room settings, e.g. spectators_see_target
+	TypeGuess     = "guess"    // Also sent by the server to announce another player's guess
+	TypeChat      = "chat"     // Chat text or emote; also relayed by the server
+	TypeMute      = "mute"     // Stop receiving a player's chat
+	TypeUnmute    = "unmute"
+)
+
+// Server-to-client message types
+const (
+	TypeWelcome      = "welcome"
+	TypeRooms        = "rooms"
+	TypeHistory      = "history"
+	TypePlayerJoined = "player_joined"
+	TypePlayerLeft   = "player_left"
+	TypePlayerAway   = "player_away"
+	TypePlayerBack   = "player_back"
+	TypeGameStarted  = "game_started"
+	TypeTurnStart    = "turn_start"
+	TypeHint         = "hint"
+	TypeTimeout      = "timeout"
+	TypeWin          = "win"
+	TypeGameOver     = "game_over"
+	TypeGameAborted  = "game_aborted"
+	TypeAnnouncement = "announcement" // Message from the server operator
+	TypeError        = "error"
+)
+
+// Error codes carried in the "code" field of error messages
+const (
+	CodeMalformed          = "malformed"           // Line is not a valid message
+	CodeUnknownType        = "unknown_type"        // Message type not understood
+	CodeUnsupportedVersion = "unsupported_version" // Client speaks another version
+	CodeJoinRequired       = "join_required"       // First message was not join
+	CodeInvalidName        = "invalid_name"
+	CodeNameTaken          = "name_taken"
+	CodeGameFull           = "game_full"
+	CodeGameStarted        = "game_started"
+	CodeGameNotStarted     = "game_not_started"
+	CodeGameOver           = "game_over"
+	CodeNotYourTurn        = "not_your_turn"
+	CodeNotFound           = "not_found" // Unknown room, or no open room to join
+	CodeNotHost            = "not_host"
+	CodeInvalidSession     = "invalid_session"  // Unknown, expired or forfeited session token
+	CodeReadOnly           = "read_only"        // Spectators cannot play
+	CodeRateLimited        = "rate_limited"     // Too many messages; try again shortly
+	CodeStaleTurn          = "stale_turn"       // Second guess for a turn, or a guess for an old turn
+	CodeKicked             = "kicked"           // Removed from the room by the server operator
+	CodeSeatedSpectator    = "seated_spectator" // Players cannot spectate their own room
+	CodeInternal           = "internal"
+)
+
+// ErrMalformed is returned by Reader.Read for lines that cannot be decoded
+var ErrMalformed = errors.New("protocol: malformed message")
+
+/*
+Message is a single protocol message in either direction.
+
+Only the fields that apply to Type are populated; see the package
+documentation for which fields each message type carries.
+*/
+type Message struct {
+	Type    string `json:"type"`
+	Version int    `json:"v,omitempty"`
+
+	// Rooms
+	Room  string     `json:"room,omitempty"`  // Invite code
+	Host  string     `json:"host,omitempty"`  // Player allowed to start the game
+	Rooms []RoomInfo `json:"rooms,omitempty"` // Lobby listing
+
+	// Spectating
+	Spectator           bool `json:"spectator,omitempty"`             // Welcome is for a spectator
+	SpectatorsSeeTarget bool `json:"spectators_see_target,omitempty"` // Room setting (create, settings)
+	Low                 int  `json:"low,omitempty"`                   // Feasible range implied by
+	High                int  `json:"high,omitempty"`                  // the hints so far
+
+	// Identity and seating
+	Token    string   `json:"token,omitempty"` // Session token for resume
+	Name     string   `json:"name,omitempty"`
+	Player   string   `json:"player,omitempty"`
+	Players  []string `json:"players,omitempty"`
+	Capacity int      `json:"capacity,omitempty"`
+
+	// Game configuration
+	Difficulty  string `json:"difficulty,omitempty"`
+	MaxRange    int    `json:"max_range,omitempty"`
+	TimeLimitMS int64  `json:"time_limit_ms,omitempty"`
+
+	// Turns and guesses
+	YourTurn   bool   `json:"your_turn,omitempty"`
+	Turn       int    `json:"turn,omitempty"` // Turn number; a guess must name the turn it answers
+	TimeLeftMS int64  `json:"time_left_ms,omitempty"`
+	Guess      string `json:"guess,omitempty"`
+	Value      int    `json:"value,omitempty"`
+	Valid      bool   `json:"valid,omitempty"`
+	Correct    bool   `json:"correct,omitempty"`
+	Hint       string `json:"hint,omitempty"`
+	Attempts   int    `json:"attempts,omitempty"`
+
+	// Results
+	Score  int            `json:"score,omitempty"`
+	Winner string         `json:"winner,omitempty"`
+	Target int            `json:"target,omitempty"`
+	Scores map[string]int `json:"scores,omitempty"`
+
+	// Chat
+	Text  string `json:"text,omitempty"`
+	Emote string `json:"emote,omitempty"` // Emote name such as "gg"
+
+	// Every turn played so far, sent after resuming
+	History []Turn `json:"history,omitempty"`
+
+	// Errors
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+/*
+RoomInfo describes a room in the lobby listing.
+*/
+type RoomInfo struct {
+	Code        string `json:"code"`
+	Host        string `json:"host"`
+	Difficulty  string `json:"difficulty"`
+	MaxRange    int    `json:"max_range"`
+	TimeLimitMS int64  `json:"time_limit_ms"`
+	Players     int    `json:"players"`
+	Capacity    int    `json:"capacity"`
+	Started     bool   `json:"started"`
+	Spectators  int    `json:"spectators"`
+
+	SpectatorsSeeTarget bool `json:"spectators_see_target,omitempty"`
+}
+
+/*
+Turn is one entry in the guess history.
+*/
+type Turn struct {
+	Player   string `json:"player"`
+	Value    int    `json:"value,omitempty"`
+	Valid    bool   `json:"valid,omitempty"`
+	Correct  bool   `json:"correct,omitempty"`
+	TimedOut bool   `json:"timed_out,omitempty"`
+	Hint     string `json:"hint,omitempty"`
+}
+
+/*
+Announcement is the UDP datagram a server broadcasts on the local network
+so that players can find its games without knowing its address.
+
+The sender's IP address comes from the datagram itself; Port is the TCP
+port to connect to. Rooms lists at most MaxAnnouncedRooms of the rooms with
+free seats, fewer if they would not fit in MaxAnnouncement bytes, and
+OpenRooms counts all of them; clients list the rest over TCP
+with list_rooms.
+*/
+type Announcement struct {
+	Type      string     `json:"type"` // Always TypeAnnounce
+	Version   int        `json:"v"`
+	Server    string     `json:"server"` // Host name of the server machine
+	Port      int        `json:"port"`
+	Rooms     []RoomInfo `json:"rooms"`
+	OpenRooms int        `json:"open_rooms"` // Rooms with free seats, listed or not
+}
+
+// TypeAnnounce identifies LAN announcements
+const TypeAnnounce = "announce"
+
+/*
+Join builds a join message for the current protocol version. An empty room
+joins any open room.
+*/
+func Join(name, room string) Message {
+	return Message{Type: TypeJoin, Version: Version, Name: name, Room: room}
+}
+
+/*
+Resume builds a message reclaiming a seat after a dropped connection.
+*/
+func Resume(room, token string) Message {
+	return Message{Type: TypeResume, Version: Version, Room: room, Token: token}
+}
+
+/*
+Watch builds a message joining a room as a read-only spectator.
+*/
+func Watch(room string) Message {
+	return Message{Type: TypeWatch, Version: Version, Room: room}
+}
+
+/*
+Chat builds a chat message; pass either text or an emote name.
+*/
+func Chat(text, emote string) Message {
+	return Message{Type: TypeChat, Text: text, Emote: emote}
+}
+
+/*
+Create builds a message creating a new room hosted by name.
+*/
+func Create(name, difficulty string, capacity int, timeLimitMS int64) Message {
+	return Message{
+		Type:        TypeCreate,
+		Version:     Version,
+		Name:        name,
+		Difficulty:  difficulty,
+		Capacity:    capacity,
+		TimeLimitMS: timeLimitMS,
+	}
+}
+
+/*
+Guess builds a guess message answering the given turn, the number from the
+player's turn_start.
+*/
+func Guess(text string, turn int) Message {
+	return Message{Type: TypeGuess, Guess: text, Turn: turn}
+}
+
+/*
+Error builds an error message.
+*/
+func Error(code, message string) Message {
+	return Message{Type: TypeError, Code: code, Message: message}
+}
+
+/*
+Err converts an error message into a Go error.
+
+Returns:
+- error: *RemoteError for error messages, nil otherwise
+*/
+func (m Message) Err() error {
+	if m.Type != TypeError {
+		return nil
+	}
+	return &RemoteError{Code: m.Code, Message: m.Message}
+}
+
+/*
+RemoteError is an error reported by the other side of the connection.
+*/
+type RemoteError struct {
+	Code    string
+	Message string
+}
+
+func (e *RemoteError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+/*
+Reader decodes messages from a stream, one per line.
+*/
+type Reader struct {
+	scanner *bufio.Scanner
+}
+
+/*
+NewReader creates a Reader enforcing MaxMessageSize.
+*/
+func NewReader(r io.Reader) *Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, MaxMessageSize), MaxMessageSize)
+	return &Reader{scanner: scanner}
+}
+
+/*
+Read returns the next message.
+
+Returns:
+  - Message: Decoded message
+  - error: ErrMalformed for undecodable lines (the stream remains usable),
+    io.EOF at end of stream, or the underlying read error
+*/
+func (r *Reader) Read() (Message, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return Message{}, err
+		}
+		return Message{}, io.EOF
+	}
+
+	var msg Message
+	if err := json.Unmarshal(r.scanner.Bytes(), &msg); err != nil || msg.Type == "" {
+		return Message{}, ErrMalformed
+	}
+	return msg, nil
+}
+
+/*
+Writer encodes messages to a stream, one per line.
+
+Writes are serialized so a Writer may be shared between goroutines.
+*/
+type Writer struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+/*
+NewWriter creates a Writer.
+*/
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{encoder: json.NewEncoder(w)}
+}
+
+/*
+Write sends a single message followed by a newline.
+*/
+func (w *Writer) Write(msg Message) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.encoder.Encode(msg)
+}
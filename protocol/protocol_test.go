@@ -0,0 +1,113 @@
+package protocol
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriterReaderRoundTrip(t *testing.T) {
+	messages := []Message{
+		Join("Alice", "ABCD"),
+		Create("Bob", "hard", 3, 30000),
+		Resume("ABCD", "secret"),
+		Watch("ABCD"),
+		Guess("42", 3),
+		Chat("", "wave"),
+		Error(CodeNameTaken, "name already taken"),
+	}
+
+	var buf bytes.Buffer
+	writer := NewWriter(&buf)
+	for _, msg := range messages {
+		if err := writer.Write(msg); err != nil {
+			t.Fatalf("writing %q: %v", msg.Type, err)
+		}
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != len(messages) {
+		t.Fatalf("wrote %d lines, want one per message (%d)", lines, len(messages))
+	}
+
+	reader := NewReader(&buf)
+	for _, want := range messages {
+		got, err := reader.Read()
+		if err != nil {
+			t.Fatalf("reading %q: %v", want.Type, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	}
+	if _, err := reader.Read(); err != io.EOF {
+		t.Errorf("read past the end: got %v, want io.EOF", err)
+	}
+}
+
+func TestBuilders(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  Message
+		want string
+	}{
+		{"join", Join("Alice", ""), `{"type":"join","v":2,"name":"Alice"}`},
+		{"guess names its turn", Guess("42", 3), `{"type":"guess","turn":3,"guess":"42"}`},
+		{"watch", Watch("ABCD"), `{"type":"watch","v":2,"room":"ABCD"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewWriter(&buf).Write(tt.msg); err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.TrimSuffix(buf.String(), "\n"); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReaderMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{"not json", "hello"},
+		{"no type", `{"guess":"42"}`},
+		{"wrong field type", `{"type":"guess","turn":"three"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := NewReader(strings.NewReader(tt.line + "\n" + `{"type":"start"}` + "\n"))
+			if _, err := reader.Read(); !errors.Is(err, ErrMalformed) {
+				t.Fatalf("got %v, want ErrMalformed", err)
+			}
+			msg, err := reader.Read()
+			if err != nil || msg.Type != TypeStart {
+				t.Errorf("next line: got %+v, %v; want a start message", msg, err)
+			}
+		})
+	}
+}
+
+func TestReaderMaxMessageSize(t *testing.T) {
+	line := `{"type":"chat","text":"` + strings.Repeat("a", MaxMessageSize) + `"}` + "\n"
+	if _, err := NewReader(strings.NewReader(line)).Read(); !errors.Is(err, bufio.ErrTooLong) {
+		t.Errorf("got %v, want bufio.ErrTooLong", err)
+	}
+}
+
+func TestMessageErr(t *testing.T) {
+	if err := Guess("42", 1).Err(); err != nil {
+		t.Errorf("guess message: got %v, want nil", err)
+	}
+
+	var remote *RemoteError
+	err := Error(CodeGameFull, "room is full").Err()
+	if !errors.As(err, &remote) || remote.Code != CodeGameFull || remote.Message != "room is full" {
+		t.Errorf("got %v, want a RemoteError with code %s", err, CodeGameFull)
+	}
+}
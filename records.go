@@ -0,0 +1,322 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+/*
+RecordCategory describes one kind of record tracked in the record book.
+
+Categories are table-driven so that adding a new record only requires a new
+entry in recordCategories and a measurement in observeSession.
+*/
+type RecordCategory struct {
+	Key           string                   // Stable identifier used as the map key
+	Label         string                   // Human-readable name for announcements
+	LowerIsBetter bool                     // True when smaller values beat larger ones
+	Format        func(value int64) string // Renders a value for display
+}
+
+// recordCategories lists every tracked record in display order
+var recordCategories = []RecordCategory{
+	{
+		Key:           "fewest_attempts",
+		Label:         "Fewest Attempts",
+		LowerIsBetter: true,
+		Format:        func(v int64) string { return fmt.Sprintf("%d attempts", v) },
+	},
+	{
+		Key:           "fastest_win",
+		Label:         "Fastest Win",
+		LowerIsBetter: true,
+		Format:        func(v int64) string { return time.Duration(v).Round(100 * time.Millisecond).String() },
+	},
+	{
+		Key:    "highest_score",
+		Label:  "Highest Score",
+		Format: func(v int64) string { return fmt.Sprintf("%d points", v) },
+	},
+	{
+		Key:    "longest_losing_streak",
+		Label:  "Longest Losing Streak Survived",
+		Format: func(v int64) string { return fmt.Sprintf("%d games", v) },
+	},
+}
+
+/*
+Record holds the current best value for a single category.
+*/
+type Record struct {
+	Holder    string    // Player who set the record
+	Value     int64     // Attempts, nanoseconds, points or games depending on category
+	Timestamp time.Time // When the record was set
+}
+
+// RecordSet maps category keys to the best value recorded so far
+type RecordSet map[string]Record
+
+/*
+RecordBook contains global and personal records for every difficulty.
+
+Records are derived from the game history rather than stored separately, so
+the book is always consistent with the persisted sessions. Adaptive games
+are keyed by the fixed difficulty their range is closest to (see
+recordDifficulty).
+
+Structure:
+- Global: difficulty -> category -> record
+- Personal: player -> difficulty -> category -> record
+*/
+type RecordBook struct {
+	Global   map[string]RecordSet
+	Personal map[string]map[string]RecordSet
+
+	// losingStreaks tracks consecutive losses per player per difficulty
+	// while the history is being replayed
+	losingStreaks map[string]map[string]int
+}
+
+/*
+RecordBreak describes a record improved by a session.
+*/
+type RecordBreak struct {
+	Category RecordCategory
+	Previous Record
+	Current  Record
+	Personal bool // True for a personal best, false for a global record
+}
+
+/*
+computeRecordBook replays the game history to build the record book.
+
+Parameters:
+- history []GameSession: Sessions in chronological order
+
+Returns:
+- *RecordBook: Global and personal records per difficulty
+*/
+func computeRecordBook(history []GameSession) *RecordBook {
+	book := &RecordBook{
+		Global:        make(map[string]RecordSet),
+		Personal:      make(map[string]map[string]RecordSet),
+		losingStreaks: make(map[string]map[string]int),
+	}
+	for _, session := range history {
+		book.observeSession(session)
+	}
+	return book
+}
+
+/*
+observeSession updates the record book with a single completed session.
+
+Losing streaks are counted per player per difficulty; a streak is only
+"survived" - and therefore eligible for a record - once the player wins.
+*/
+func (book *RecordBook) observeSession(session GameSession) {
+	difficulty := recordDifficulty(session)
+	streaks, exists := book.losingStreaks[difficulty]
+	if !exists {
+		streaks = make(map[string]int)
+		book.losingStreaks[difficulty] = streaks
+	}
+
+	for _, player := range sessionPlayers(session) {
+		if player != session.Winner {
+			streaks[player]++
+		}
+	}
+
+	if session.Winner == "" {
+		return
+	}
+
+	measurements := map[string]int64{
+		"fastest_win":   int64(session.Duration),
+		"highest_score": int64(comparableScore(session)),
+	}
+	if turns := winnerTurns(session); turns > 0 {
+		measurements["fewest_attempts"] = int64(turns)
+	}
+	if streak := streaks[session.Winner]; streak > 0 {
+		measurements["longest_losing_streak"] = int64(streak)
+	}
+	streaks[session.Winner] = 0
+
+	for _, category := range recordCategories {
+		value, measured := measurements[category.Key]
+		if !measured {
+			continue
+		}
+		record := Record{Holder: session.Winner, Value: value, Timestamp: session.Timestamp}
+		book.globalSet(difficulty).offer(category, record)
+		book.personalSet(session.Winner, difficulty).offer(category, record)
+	}
+}
+
+/*
+winnerTurns returns how many guesses the winner of a session made. Older
+multiplayer sessions only recorded the total across all players, which
+says nothing about the winner's own efficiency, so they report 0.
+*/
+func winnerTurns(session GameSession) int {
+	if session.WinnerTurns > 0 {
+		return session.WinnerTurns
+	}
+	if session.PlayerCount <= 1 {
+		return session.Attempts // Solo games: every attempt was the winner's
+	}
+	return 0
+}
+
+/*
+comparableScore returns the winner's score without any handicap multiplier,
+so a handicapped win cannot beat better play on score alone. Leaderboard
+totals still count the handicapped points actually awarded.
+*/
+func comparableScore(session GameSession) int {
+	if session.UnhandicappedScore > 0 {
+		return session.UnhandicappedScore
+	}
+	return session.FinalScore
+}
+
+// globalSet returns (creating if needed) the global records for a difficulty
+func (book *RecordBook) globalSet(difficulty string) RecordSet {
+	set, exists := book.Global[difficulty]
+	if !exists {
+		set = make(RecordSet)
+		book.Global[difficulty] = set
+	}
+	return set
+}
+
+// personalSet returns (creating if needed) a player's records for a difficulty
+func (book *RecordBook) personalSet(player, difficulty string) RecordSet {
+	byDifficulty, exists := book.Personal[player]
+	if !exists {
+		byDifficulty = make(map[string]RecordSet)
+		book.Personal[player] = byDifficulty
+	}
+	set, exists := byDifficulty[difficulty]
+	if !exists {
+		set = make(RecordSet)
+		byDifficulty[difficulty] = set
+	}
+	return set
+}
+
+/*
+offer stores a candidate record if it strictly beats the current one.
+
+Returns:
+- bool: True if the candidate became the new record
+*/
+func (set RecordSet) offer(category RecordCategory, candidate Record) bool {
+	current, exists := set[category.Key]
+	if exists {
+		if category.LowerIsBetter && candidate.Value >= current.Value {
+			return false
+		}
+		if !category.LowerIsBetter && candidate.Value <= current.Value {
+			return false
+		}
+	}
+	set[category.Key] = candidate
+	return true
+}
+
+/*
+findRecordBreaks reports which records a new session breaks.
+
+A record only counts as broken when a previous value existed - setting the
+very first record for a difficulty is not announced as a new record.
+
+Parameters:
+- history []GameSession: Sessions before the new one
+- session GameSession: Newly completed session
+
+Returns:
+- []RecordBreak: Global records first, then personal bests
+*/
+func findRecordBreaks(history []GameSession, session GameSession) []RecordBreak {
+	before := computeRecordBook(history)
+	after := computeRecordBook(history)
+	after.observeSession(session)
+
+	difficulty := recordDifficulty(session)
+	var global, personal []RecordBreak
+	for _, category := range recordCategories {
+		previous, existed := before.Global[difficulty][category.Key]
+		current := after.Global[difficulty][category.Key]
+		if existed && current != previous {
+			global = append(global, RecordBreak{Category: category, Previous: previous, Current: current})
+		}
+
+		previous, existed = before.Personal[session.Winner][difficulty][category.Key]
+		current = after.Personal[session.Winner][difficulty][category.Key]
+		if existed && current != previous {
+			personal = append(personal, RecordBreak{Category: category, Previous: previous, Current: current, Personal: true})
+		}
+	}
+
+	return append(global, personal...)
+}
+
+/*
+announceRecordBreaks prints celebratory messages for broken records.
+
+Parameters:
+- breaks []RecordBreak: Records improved by the session
+*/
+func announceRecordBreaks(breaks []RecordBreak) {
+	if len(breaks) == 0 {
+		return
+	}
+
+	fmt.Printf("\n%sRecords:%s\n", ColorCyan, ColorReset)
+	for _, recordBreak := range breaks {
+		category := recordBreak.Category
+		if recordBreak.Personal {
+			fmt.Printf("  %s⭐ New personal best!%s %s: %s%s%s (was %s)\n",
+				ColorGreen, ColorReset, category.Label,
+				ColorYellow, category.Format(recordBreak.Current.Value), ColorReset,
+				category.Format(recordBreak.Previous.Value))
+		} else {
+			fmt.Printf("  %s🏆 New record!%s %s: %s%s%s by %s (was %s by %s)\n",
+				ColorPurple, ColorReset, category.Label,
+				ColorYellow, category.Format(recordBreak.Current.Value), ColorReset,
+				recordBreak.Current.Holder,
+				category.Format(recordBreak.Previous.Value), recordBreak.Previous.Holder)
+		}
+	}
+}
+
+/*
+displayRecordBook prints the global record holders for each difficulty.
+
+Parameters:
+- book *RecordBook: Records computed from the game history
+*/
+func displayRecordBook(book *RecordBook) {
+	if len(book.Global) == 0 {
+		return
+	}
+
+	fmt.Printf("\n%s Record Book:%s\n", ColorPurple, ColorReset)
+	for _, difficulty := range recordDifficulties() {
+		set, exists := book.Global[difficulty]
+		if !exists {
+			continue
+		}
+
+		fmt.Printf("  %s%s:%s\n", ColorBlue, difficultyTitle(difficulty), ColorReset)
+		for _, category := range recordCategories {
+			if record, exists := set[category.Key]; exists {
+				fmt.Printf("    %s: %s%s%s (%s)\n",
+					category.Label, ColorWhite, category.Format(record.Value), ColorReset, record.Holder)
+			}
+		}
+	}
+}
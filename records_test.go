@@ -0,0 +1,111 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+/*
+recordSession builds a finished session for the record tests; the winner is
+the only player unless others are given.
+*/
+func recordSession(day int, winner string, turns int, duration time.Duration, score int, players ...string) GameSession {
+	if len(players) == 0 {
+		players = []string{winner}
+	}
+	return GameSession{
+		Difficulty:  "easy",
+		Winner:      winner,
+		Players:     players,
+		Attempts:    turns * len(players),
+		WinnerTurns: turns,
+		Duration:    duration,
+		PlayerCount: len(players),
+		FinalScore:  score,
+		Timestamp:   time.Date(2026, 10, day, 12, 0, 0, 0, time.Local),
+	}
+}
+
+func TestComputeRecordBook(t *testing.T) {
+	history := []GameSession{
+		recordSession(1, "Alice", 5, 30*time.Second, 600),
+		recordSession(2, "Bob", 3, 40*time.Second, 900, "Alice", "Bob"),
+		recordSession(3, "", 0, 20*time.Second, 0, "Alice"),
+		recordSession(4, "", 0, 20*time.Second, 0, "Alice"),
+		recordSession(5, "Alice", 4, 10*time.Second, 700),
+	}
+	handicapped := recordSession(6, "Carol", 6, time.Minute, 1800)
+	handicapped.UnhandicappedScore = 500
+	history = append(history, handicapped)
+
+	book := computeRecordBook(history)
+	tests := []struct {
+		name     string
+		set      RecordSet
+		category string
+		holder   string
+		value    int64
+	}{
+		{"fewest attempts counts the winner's own turns", book.Global["easy"], "fewest_attempts", "Bob", 3},
+		{"fastest win", book.Global["easy"], "fastest_win", "Alice", int64(10 * time.Second)},
+		{"highest score ignores handicap multipliers", book.Global["easy"], "highest_score", "Bob", 900},
+		{"losing streak counts losses until the next win", book.Global["easy"], "longest_losing_streak", "Alice", 3},
+		{"personal best", book.Personal["Alice"]["easy"], "fewest_attempts", "Alice", 4},
+		{"personal best with a handicap", book.Personal["Carol"]["easy"], "highest_score", "Carol", 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record, exists := tt.set[tt.category]
+			if !exists {
+				t.Fatalf("no %s record", tt.category)
+			}
+			if record.Holder != tt.holder || record.Value != tt.value {
+				t.Errorf("got %d by %s, want %d by %s", record.Value, record.Holder, tt.value, tt.holder)
+			}
+		})
+	}
+
+	if _, exists := book.Global["medium"]; exists {
+		t.Error("records kept for a difficulty nobody played")
+	}
+}
+
+func TestFindRecordBreaks(t *testing.T) {
+	history := []GameSession{
+		recordSession(1, "Alice", 5, 30*time.Second, 600),
+		recordSession(2, "Bob", 4, 50*time.Second, 500),
+	}
+	adaptive := recordSession(3, "Alice", 1, time.Second, 2000)
+	adaptive.Difficulty, adaptive.MaxRange = DifficultyAdaptive, 60
+
+	tests := []struct {
+		name    string
+		history []GameSession
+		session GameSession
+		want    []string // Category keys, "*" marking personal bests
+	}{
+		{"first game sets no records", nil, recordSession(1, "Alice", 5, 30*time.Second, 600), nil},
+		{"global and personal records", history, recordSession(3, "Alice", 3, 20*time.Second, 650),
+			[]string{"fewest_attempts", "fastest_win", "highest_score", "fewest_attempts*", "fastest_win*", "highest_score*"}},
+		{"personal best only", history, recordSession(3, "Bob", 4, 40*time.Second, 550),
+			[]string{"fastest_win*", "highest_score*"}},
+		{"ties do not break records", history, recordSession(3, "Alice", 5, 30*time.Second, 600), nil},
+		{"adaptive games are compared separately", history, adaptive, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, recordBreak := range findRecordBreaks(tt.history, tt.session) {
+				key := recordBreak.Category.Key
+				if recordBreak.Personal {
+					key += "*"
+				}
+				got = append(got, key)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got breaks %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
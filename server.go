@@ -0,0 +1,714 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"gaming/my-guessing-game/protocol"
+)
+
+// Network server constants - Generous enough for slow links, strict enough
+// that idle or hostile connections cannot hold resources indefinitely
+const (
+	DefaultServeAddr = ":7777"          // Default TCP listen address
+	JoinTimeout      = 30 * time.Second // Time a new connection has to send "join" or "create"
+	ShutdownGrace    = 2 * time.Second  // Time given to clients to receive final messages
+)
+
+/*
+gameServer hosts a lobby of rooms over TCP.
+
+Each connection is one player in one room. The server never trusts clients
+with game state: turn order, timeouts and guess validation all happen in the
+room's Match, the target stays on the server until the game ends (unless
+the host reveals it to spectators), and each connection is policed by a
+connGuard.
+*/
+type gameServer struct {
+	lobby    *Lobby
+	listener net.Listener
+	clients  sync.WaitGroup
+}
+
+/*
+runServeCommand hosts networked games over TCP.
+
+Usage:
+
+	guessing-game serve [-addr :7777] [-http :8080] [-difficulty medium] [-players 2] [-time-limit 10s] [-grace 1m] [-announce addr] [-admin 127.0.0.1:7780] [-metrics :9100]
+
+Players create rooms and share the invite code, or join any open room. The
+flags set the defaults for rooms whose creator does not choose. With -http,
+the JSON API and browser UI are served on the same lobby. Finished games
+are saved like local games; the server runs until interrupted. Players
+whose connection drops may resume their seat within the -grace period.
+Open rooms are announced on the local network for "discover" unless
+-announce is empty. With -admin, the operator can manage rooms through the
+admin API (see adminServer and the "admin" command); with -metrics,
+Prometheus can scrape /metrics.
+
+Parameters:
+- args []string: Subcommand arguments
+
+Returns:
+- int: Process exit code
+*/
+func runServeCommand(args []string) int {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", DefaultServeAddr, "TCP address to listen on")
+	httpAddr := flags.String("http", "", "also serve the HTTP API and browser UI on this address")
+	difficulty := flags.String("difficulty", "medium", "default difficulty: easy, medium or hard")
+	players := flags.Int("players", 2, fmt.Sprintf("default number of seats per room (1-%d)", MaxPlayers))
+	timeLimit := flags.Duration("time-limit", DefaultTimeLimit, "default time allowed per guess")
+	grace := flags.Duration("grace", DefaultReconnectGrace, "how long a disconnected player's seat is held")
+	announce := flags.String("announce", DefaultAnnounceAddr, "UDP address to announce open rooms to (empty disables)")
+	adminAddr := flags.String("admin", "", "serve the admin API on this address, e.g. "+DefaultAdminAddr)
+	adminToken := flags.String("admin-token", os.Getenv(AdminTokenEnv), "admin API token (default $"+AdminTokenEnv+", or generated)")
+	metricsAddr := flags.String("metrics", "", "serve Prometheus metrics at /metrics on this address")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	defaults, err := RoomSettings{Difficulty: *difficulty, Capacity: *players, TimeLimit: *timeLimit}.withDefaults(RoomSettings{})
+	if err != nil {
+		printColoredMessage(err.Error(), ColorRed)
+		return 2
+	}
+	if *grace < 0 {
+		printColoredMessage("Grace period must not be negative.", ColorRed)
+		return 2
+	}
+	if loadSaveDataOrReport() == nil {
+		return 1
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		printColoredMessage(fmt.Sprintf("Could not listen on %s: %v", *addr, err), ColorRed)
+		return 1
+	}
+
+	server := &gameServer{
+		lobby:    newLobby(dataFilePath(), defaults, *grace),
+		listener: listener,
+	}
+
+	printColoredHeader("🌐 Network Game Server")
+	fmt.Printf("%sListening on:%s %s\n", ColorBlue, ColorReset, listener.Addr())
+	if *httpAddr != "" {
+		fmt.Printf("%sHTTP:%s http://%s/\n", ColorBlue, ColorReset, *httpAddr)
+	}
+	if *announce != "" {
+		fmt.Printf("%sLAN announcements:%s %s\n", ColorBlue, ColorReset, *announce)
+	}
+	fmt.Printf("%sRoom defaults:%s %s (1-%d), %d players, %s per guess\n", ColorBlue, ColorReset,
+		strings.Title(defaults.Difficulty), getMaxRange(defaults.Difficulty), defaults.Capacity, defaults.TimeLimit)
+	stopAdmin, err := startAdminServer(*adminAddr, *adminToken, server.lobby)
+	if err != nil {
+		printColoredMessage(fmt.Sprintf("Could not listen on %s: %v", *adminAddr, err), ColorRed)
+		listener.Close()
+		return 1
+	}
+	defer stopAdmin()
+	stopMetrics, err := startMetricsServer(*metricsAddr, server.lobby)
+	if err != nil {
+		printColoredMessage(fmt.Sprintf("Could not listen on %s: %v", *metricsAddr, err), ColorRed)
+		listener.Close()
+		return 1
+	}
+	defer stopMetrics()
+	printSeparator()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *httpAddr != "" {
+		httpServer := newHTTPServer(*httpAddr, server.lobby)
+		go func() {
+			if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				printColoredMessage(fmt.Sprintf("HTTP server stopped: %v", err), ColorRed)
+				stop()
+			}
+		}()
+		defer httpServer.Close()
+	}
+	if *announce != "" {
+		go announceRooms(ctx, server.lobby, listener.Addr().(*net.TCPAddr).Port, *announce)
+	}
+
+	return server.run(ctx)
+}
+
+/*
+run accepts players until the context is cancelled.
+
+Returns:
+- int: Process exit code
+*/
+func (s *gameServer) run(ctx context.Context) int {
+	go func() {
+		<-ctx.Done()
+		s.listener.Close()
+	}()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			break // Listener closed on shutdown
+		}
+		s.clients.Add(1)
+		go s.handleConn(conn)
+	}
+
+	printColoredMessage("Shutting down; games in progress are abandoned.", ColorYellow)
+	for _, room := range s.lobby.Rooms() {
+		room.Match.Abort()
+	}
+
+	// Give connection writers a moment to deliver the final messages
+	finished := make(chan struct{})
+	go func() {
+		s.clients.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-time.After(ShutdownGrace):
+	}
+	return 0
+}
+
+/*
+handleConn runs the protocol for one connected player.
+
+Connection Lifecycle:
+ 1. The client may send list_rooms any number of times, then must send
+    join, create, resume or watch; each message must arrive within
+    JoinTimeout (watch hands the connection to spectate)
+ 2. Match events are forwarded to the client by a writer goroutine
+ 3. Guesses, chat and mutes (and start, from the host) are read line by
+    line
+ 4. The connection closes after game_over, or when the client disconnects;
+    a dropped player's seat is held for the lobby's grace period
+*/
+func (s *gameServer) handleConn(conn net.Conn) {
+	defer s.clients.Done()
+	defer conn.Close()
+
+	reader := protocol.NewReader(conn)
+	writer := protocol.NewWriter(conn)
+	guard := newConnGuard(conn.RemoteAddr().String())
+
+	// Phase 1: Lobby - list rooms until the client joins or creates one
+	var room *Room
+	var hello protocol.Message
+	var name string
+	for room == nil {
+		conn.SetReadDeadline(time.Now().Add(JoinTimeout))
+		msg, err := reader.Read()
+		if err != nil {
+			writer.Write(protocol.Error(protocol.CodeJoinRequired, "first message must be join or create"))
+			return
+		}
+		if !guard.Allow() {
+			writer.Write(protocol.Error(protocol.CodeRateLimited, "too many messages"))
+			return
+		}
+		if msg.Version != 0 && msg.Version != protocol.Version {
+			writer.Write(protocol.Error(protocol.CodeUnsupportedVersion,
+				fmt.Sprintf("server speaks protocol v%d", protocol.Version)))
+			return
+		}
+
+		switch msg.Type {
+		case protocol.TypeListRooms:
+			writer.Write(protocol.Message{Type: protocol.TypeRooms, Rooms: s.lobby.OpenRooms()})
+			continue
+		case protocol.TypeJoin:
+			if msg.Room == "" {
+				room, err = s.lobby.OpenRoom()
+			} else {
+				room, err = s.lobby.Room(msg.Room)
+			}
+		case protocol.TypeResume:
+			room, err = s.lobby.Room(msg.Room)
+			if err == nil {
+				name, err = room.Player(msg.Token)
+			}
+		case protocol.TypeWatch:
+			room, err = s.lobby.Room(msg.Room)
+			if err == nil {
+				err = room.CheckSpectator(msg.Token, conn.RemoteAddr().String())
+			}
+		case protocol.TypeCreate:
+			room, err = s.lobby.CreateRoom(RoomSettings{
+				Difficulty: msg.Difficulty,
+				Capacity:   msg.Capacity,
+				TimeLimit:  time.Duration(msg.TimeLimitMS) * time.Millisecond,
+
+				SpectatorsSeeTarget: msg.SpectatorsSeeTarget,
+			})
+		default:
+			writer.Write(protocol.Error(protocol.CodeJoinRequired, "first message must be join or create"))
+			return
+		}
+		if errors.Is(err, ErrUnknownSession) {
+			guard.Strike("resume with an unknown session token")
+		}
+		if err != nil {
+			writer.Write(errorMessage(err))
+			return
+		}
+		hello = msg
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	if hello.Type == protocol.TypeWatch {
+		guard.Identify(room.Code, "spectator "+conn.RemoteAddr().String())
+		s.spectate(conn, reader, writer, guard, room)
+		return
+	}
+
+	events, unsubscribe := room.Match.Subscribe()
+	defer unsubscribe()
+
+	token := hello.Token
+	if hello.Type != protocol.TypeResume {
+		name = strings.TrimSpace(hello.Name)
+		var err error
+		if token, err = room.Join(name); err != nil {
+			writer.Write(errorMessage(err))
+			if hello.Type == protocol.TypeCreate {
+				s.lobby.remove(room) // Nobody else knows the code yet
+			}
+			return
+		}
+	}
+	detach := room.Attach(name, func() { conn.Close() })
+	defer detach()
+	room.PlayingFrom(name, conn.RemoteAddr().String())
+	guard.Identify(room.Code, name)
+
+	snapshot := room.Match.Snapshot()
+	writer.Write(protocol.Message{
+		Type:        protocol.TypeWelcome,
+		Version:     protocol.Version,
+		Room:        room.Code,
+		Host:        snapshot.Host,
+		Token:       token,
+		Name:        name,
+		Players:     snapshot.Players,
+		Capacity:    snapshot.Capacity,
+		Difficulty:  snapshot.Difficulty,
+		MaxRange:    snapshot.MaxRange,
+		TimeLimitMS: snapshot.TimeLimitMS,
+	})
+	if hello.Type == protocol.TypeResume {
+		for _, msg := range resumeMessages(snapshot, name) {
+			writer.Write(msg)
+		}
+	}
+
+	// Phase 2: Forward match events to this player
+	var muted muteList
+	go func() {
+		defer conn.Close() // Unblocks the reader below
+		for event := range events {
+			if muted.Hides(event) {
+				continue
+			}
+			for _, msg := range eventMessages(event, name) {
+				if err := writer.Write(msg); err != nil {
+					return
+				}
+			}
+			if event.Type == EventGameOver || event.Type == EventGameAborted {
+				return
+			}
+		}
+	}()
+
+	// Phase 3: Read guesses until the connection closes
+	for {
+		msg, ok := readGuarded(reader, writer, guard)
+		if !ok {
+			return
+		}
+
+		switch msg.Type {
+		case protocol.TypeGuess:
+			_, err := room.Match.SubmitGuess(name, msg.Guess, msg.Turn)
+			switch {
+			case errors.Is(err, ErrMissingTurn):
+				guard.Strike("guess without a turn")
+			case errors.Is(err, ErrNotYourTurn):
+				guard.Strike("guess out of turn")
+			case errors.Is(err, ErrStaleTurn):
+				guard.Strike("second guess in one turn")
+			}
+			if err != nil {
+				writer.Write(errorMessage(err))
+			}
+		case protocol.TypeStart:
+			if err := room.Match.StartBy(name); err != nil {
+				writer.Write(errorMessage(err))
+			}
+		case protocol.TypeSettings:
+			if err := room.SetRevealTarget(name, msg.SpectatorsSeeTarget); err != nil {
+				writer.Write(errorMessage(err))
+				continue
+			}
+			writer.Write(protocol.Message{Type: protocol.TypeSettings, Room: room.Code,
+				SpectatorsSeeTarget: msg.SpectatorsSeeTarget})
+		case protocol.TypeChat:
+			// Chat never reaches SubmitGuess, whoever's turn it is
+			if err := room.Chat(name, msg.Text, msg.Emote); err != nil {
+				writer.Write(errorMessage(err))
+			}
+		case protocol.TypeMute, protocol.TypeUnmute:
+			muted.Set(msg.Player, msg.Type == protocol.TypeMute)
+		default:
+			guard.Strike(fmt.Sprintf("unknown message type %q", msg.Type))
+			writer.Write(protocol.Error(protocol.CodeUnknownType,
+				fmt.Sprintf("unknown message type %q", msg.Type)))
+		}
+	}
+}
+
+/*
+readGuarded reads the next message a joined connection should act on.
+
+Messages over the connection's rate limit are refused with rate_limited and
+malformed lines with malformed; both count as strikes against the guard.
+
+Returns:
+- protocol.Message: Next message to handle
+- bool: False once the connection has ended or run out of strikes
+*/
+func readGuarded(reader *protocol.Reader, writer *protocol.Writer, guard *connGuard) (protocol.Message, bool) {
+	for !guard.Exhausted() {
+		msg, err := reader.Read()
+		switch {
+		case errors.Is(err, protocol.ErrMalformed):
+			guard.Strike("malformed message")
+			writer.Write(protocol.Error(protocol.CodeMalformed, err.Error()))
+		case errors.Is(err, bufio.ErrTooLong):
+			guard.Strike("oversized message")
+			return protocol.Message{}, false
+		case err != nil:
+			return protocol.Message{}, false
+		case !guard.Allow():
+			writer.Write(protocol.Error(protocol.CodeRateLimited, "too many messages; slow down"))
+		default:
+			return msg, true
+		}
+	}
+	writer.Write(protocol.Error(protocol.CodeRateLimited, "too many rejected messages; disconnecting"))
+	return protocol.Message{}, false
+}
+
+/*
+spectate runs the protocol for a read-only spectator connection.
+
+Spectators receive every event the players see - each guess arrives as a
+"guess" announcement - together with the narrowing feasible range. When the
+host enabled spectators_see_target, the target accompanies the welcome and
+every guess and timeout. Spectators read the chat (and may mute players) but
+cannot write to it; attempts to play or chat are rejected with read_only.
+
+Players may not watch their own game: a watch carrying a seated player's
+token is refused before this runs, and the target is withheld from any
+spectator whose IP address matches that of a seated player's connection.
+*/
+func (s *gameServer) spectate(conn net.Conn, reader *protocol.Reader, writer *protocol.Writer, guard *connGuard, room *Room) {
+	events, unsubscribe := room.Match.Subscribe()
+	defer unsubscribe()
+	defer room.Watch()()
+
+	snapshot := room.Match.Snapshot()
+	writer.Write(protocol.Message{
+		Type:        protocol.TypeWelcome,
+		Version:     protocol.Version,
+		Room:        room.Code,
+		Host:        snapshot.Host,
+		Spectator:   true,
+		Players:     snapshot.Players,
+		Capacity:    snapshot.Capacity,
+		Difficulty:  snapshot.Difficulty,
+		MaxRange:    snapshot.MaxRange,
+		TimeLimitMS: snapshot.TimeLimitMS,
+		Low:         snapshot.Low,
+		High:        snapshot.High,
+		Target:      room.SpectatorTarget(conn.RemoteAddr().String()),
+	})
+	for _, msg := range resumeMessages(snapshot, "") {
+		writer.Write(msg)
+	}
+	if snapshot.Finished {
+		return
+	}
+
+	var muted muteList
+	go func() {
+		defer conn.Close() // Unblocks the reader below
+		for event := range events {
+			if muted.Hides(event) {
+				continue
+			}
+			for _, msg := range eventMessages(event, "") {
+				if msg.Type == protocol.TypeGuess || msg.Type == protocol.TypeTimeout {
+					msg.Target = room.SpectatorTarget(conn.RemoteAddr().String()) // Re-read so the host can toggle it live
+				}
+				if err := writer.Write(msg); err != nil {
+					return
+				}
+			}
+			if event.Type == EventGameOver || event.Type == EventGameAborted {
+				return
+			}
+		}
+	}()
+
+	for {
+		msg, ok := readGuarded(reader, writer, guard)
+		if !ok {
+			return
+		}
+
+		switch msg.Type {
+		case protocol.TypeGuess, protocol.TypeStart, protocol.TypeSettings, protocol.TypeChat:
+			guard.Strike("spectator tried to " + msg.Type)
+			writer.Write(protocol.Error(protocol.CodeReadOnly, "spectators cannot play or chat"))
+		case protocol.TypeMute, protocol.TypeUnmute:
+			muted.Set(msg.Player, msg.Type == protocol.TypeMute)
+		default:
+			guard.Strike(fmt.Sprintf("unknown message type %q", msg.Type))
+			writer.Write(protocol.Error(protocol.CodeUnknownType,
+				fmt.Sprintf("unknown message type %q", msg.Type)))
+		}
+	}
+}
+
+// matchErrorCodes maps match and lobby errors to stable protocol error codes
+var matchErrorCodes = map[error]string{
+	ErrInvalidName:   protocol.CodeInvalidName,
+	ErrNameTaken:     protocol.CodeNameTaken,
+	ErrMatchFull:     protocol.CodeGameFull,
+	ErrMatchStarted:  protocol.CodeGameStarted,
+	ErrMatchNotReady: protocol.CodeGameNotStarted,
+	ErrMatchOver:     protocol.CodeGameOver,
+	ErrNotYourTurn:   protocol.CodeNotYourTurn,
+	ErrNotHost:       protocol.CodeNotHost,
+	ErrMatchAborted:  protocol.CodeGameOver,
+	ErrStaleTurn:     protocol.CodeStaleTurn,
+	ErrMissingTurn:   protocol.CodeMalformed,
+
+	ErrRoomNotFound:    protocol.CodeNotFound,
+	ErrNoOpenRoom:      protocol.CodeNotFound,
+	ErrInvalidSettings: protocol.CodeMalformed,
+	ErrUnknownSession:  protocol.CodeInvalidSession,
+	ErrNoSuchPlayer:    protocol.CodeNotFound,
+	ErrKicked:          protocol.CodeKicked,
+	ErrSeatedSpectator: protocol.CodeSeatedSpectator,
+
+	ErrRateLimited: protocol.CodeRateLimited,
+	ErrInvalidChat: protocol.CodeMalformed,
+}
+
+/*
+errorMessage converts a match error into a protocol error message.
+*/
+func errorMessage(err error) protocol.Message {
+	for matchErr, code := range matchErrorCodes {
+		if errors.Is(err, matchErr) {
+			return protocol.Error(code, err.Error())
+		}
+	}
+	return protocol.Error(protocol.CodeInternal, err.Error())
+}
+
+/*
+eventMessages converts a match event into the messages a specific player
+should receive.
+
+The player who guessed receives a "hint" addressed to them; everyone else
+receives a "guess" announcement carrying the same hint, mirroring how all
+players see each other's hints in the local game. The end of the game is
+announced as a "win" followed by "game_over".
+
+Parameters:
+- event MatchEvent: Event to convert
+- self string: Player the messages are addressed to
+
+Returns:
+- []protocol.Message: Messages in delivery order
+*/
+func eventMessages(event MatchEvent, self string) []protocol.Message {
+	msg := protocol.Message{Player: event.Player, Attempts: event.Attempts}
+
+	switch event.Type {
+	case EventPlayerJoined:
+		msg.Type = protocol.TypePlayerJoined
+		msg.Players = event.Players
+		msg.Host = firstOrEmpty(event.Players)
+	case EventPlayerLeft:
+		msg.Type = protocol.TypePlayerLeft
+		msg.Players = event.Players
+		msg.Host = firstOrEmpty(event.Players)
+	case EventGameStarted:
+		msg.Type = protocol.TypeGameStarted
+		msg.Players = event.Players
+	case EventTurnStarted:
+		msg.Type = protocol.TypeTurnStart
+		msg.YourTurn = event.Player == self
+		msg.Turn = event.Turn
+		msg.TimeLeftMS = time.Until(event.Deadline).Milliseconds()
+	case EventGuess:
+		msg.Type = protocol.TypeGuess
+		if event.Player == self {
+			msg.Type = protocol.TypeHint
+		}
+		msg.Value = event.Result.Value
+		msg.Valid = event.Result.Valid
+		msg.Correct = event.Result.Correct
+		msg.Hint = event.Result.Hint
+		msg.Low, msg.High = event.Low, event.High
+	case EventTimeout:
+		msg.Type = protocol.TypeTimeout
+		msg.Hint = event.Result.Hint
+		msg.Low, msg.High = event.Low, event.High
+	case EventPlayerAway:
+		msg.Type = protocol.TypePlayerAway
+	case EventPlayerBack:
+		msg.Type = protocol.TypePlayerBack
+	case EventGameAborted:
+		msg.Type = protocol.TypeGameAborted
+	case EventChat:
+		msg.Type = protocol.TypeChat
+		msg.Text = event.Text
+		msg.Emote = event.Emote
+	case EventAnnouncement:
+		msg.Type = protocol.TypeAnnouncement
+		msg.Text = event.Text
+	case EventGameOver:
+		win := protocol.Message{
+			Type:     protocol.TypeWin,
+			Player:   event.Player,
+			Value:    event.Target,
+			Score:    event.Scores[event.Player],
+			Attempts: event.Attempts,
+		}
+		msg.Type = protocol.TypeGameOver
+		msg.Player = ""
+		msg.Winner = event.Player
+		msg.Target = event.Target
+		msg.Scores = event.Scores
+		return []protocol.Message{win, msg}
+	default:
+		return nil
+	}
+	return []protocol.Message{msg}
+}
+
+/*
+resumeMessages brings a reconnecting player up to date: the full guess
+history, followed by the turn in progress.
+
+Parameters:
+- snapshot MatchSnapshot: Current state of the room's match
+- self string: The resuming player
+
+Returns:
+- []protocol.Message: Messages in delivery order
+*/
+func resumeMessages(snapshot MatchSnapshot, self string) []protocol.Message {
+	history := protocol.Message{
+		Type:     protocol.TypeHistory,
+		Players:  snapshot.Players,
+		Attempts: snapshot.Attempts,
+		Low:      snapshot.Low,
+		High:     snapshot.High,
+		History:  make([]protocol.Turn, 0, len(snapshot.Guesses)),
+	}
+	for _, guess := range snapshot.Guesses {
+		history.History = append(history.History, protocol.Turn{
+			Player:   guess.Player,
+			Value:    guess.Value,
+			Valid:    guess.Valid,
+			Correct:  guess.Correct,
+			TimedOut: guess.TimedOut,
+			Hint:     guess.Hint,
+		})
+	}
+	messages := []protocol.Message{history}
+
+	if snapshot.Started && !snapshot.Finished {
+		messages = append(messages, protocol.Message{
+			Type:       protocol.TypeTurnStart,
+			Player:     snapshot.CurrentPlayer,
+			YourTurn:   snapshot.CurrentPlayer == self,
+			Turn:       snapshot.Turn,
+			TimeLeftMS: snapshot.TimeLeftMS,
+			Attempts:   snapshot.Attempts,
+		})
+	}
+	return messages
+}
+
+/*
+logMatchEvents prints a colored activity log for one room on the host's
+console, until the room's game ends.
+
+Parameters:
+- code string: Room invite code used as the log prefix
+- events <-chan MatchEvent: Subscription to the room's match
+*/
+func logMatchEvents(code string, events <-chan MatchEvent) {
+	prefix := "[" + code + "] "
+	for event := range events {
+		switch event.Type {
+		case EventPlayerJoined:
+			printColoredMessage(fmt.Sprintf("%s%s joined (%d seated)", prefix, event.Player, len(event.Players)), ColorGreen)
+		case EventPlayerLeft:
+			printColoredMessage(fmt.Sprintf("%s%s left (%d seated)", prefix, event.Player, len(event.Players)), ColorYellow)
+		case EventGameStarted:
+			printColoredMessage(fmt.Sprintf("%sGame started: %s", prefix, strings.Join(event.Players, ", ")), ColorPurple)
+		case EventTurnStarted:
+			fmt.Printf("%s%s[%s's Turn]%s\n", prefix, ColorBlue, event.Player, ColorReset)
+		case EventGuess:
+			if event.Result.Correct {
+				fmt.Printf("%s  %s guessed %d: correct!\n", prefix, event.Player, event.Result.Value)
+			} else {
+				fmt.Printf("%s  %s guessed %d: %s\n", prefix, event.Player, event.Result.Value, event.Result.Hint)
+			}
+		case EventTimeout:
+			printColoredMessage(fmt.Sprintf("%sTime's up, %s! Turn skipped.", prefix, event.Player), ColorRed)
+		case EventPlayerAway:
+			printColoredMessage(fmt.Sprintf("%s%s disconnected; turns skipped until they return", prefix, event.Player), ColorYellow)
+		case EventPlayerBack:
+			printColoredMessage(fmt.Sprintf("%s%s reconnected", prefix, event.Player), ColorGreen)
+		case EventChat:
+			fmt.Printf("%s%s%s:%s %s\n", prefix, ColorCyan, event.Player, ColorReset, chatLine(event.Text, event.Emote))
+		case EventAnnouncement:
+			printColoredMessage(fmt.Sprintf("%s📢 %s", prefix, event.Text), ColorPurple)
+		case EventGameOver:
+			printColoredMessage(fmt.Sprintf("%s%s wins with %d attempts for %d points!", prefix, event.Player,
+				event.Attempts, event.Scores[event.Player]), ColorGreen)
+			return
+		case EventGameAborted:
+			printColoredMessage(prefix+"Game abandoned", ColorYellow)
+			return
+		}
+	}
+}
@@ -0,0 +1,410 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Simulation defaults
+const (
+	DefaultSimulatedGames   = 1000 // Games per difficulty
+	SimulatedTurnsPerNumber = 20   // Turn cap per game, per number in the range, for bots that never converge
+)
+
+/*
+SimulationConfig describes a headless bot tournament.
+
+Every game is played by all configured bots, with the seat that moves first
+rotating from game to game so no strategy profits from going first.
+*/
+type SimulationConfig struct {
+	Strategies   []string      // One bot per entry, in seat order; repeats are allowed
+	Difficulties []string      // Difficulties to play, each with Games games
+	Games        int           // Games per difficulty
+	Seed         int64         // Base seed; the same seed always replays the same games
+	Workers      int           // Games played in parallel
+	TimeLimit    time.Duration // Time allowed per guess
+}
+
+/*
+SimulatedGame is the outcome of one headless game.
+*/
+type SimulatedGame struct {
+	Difficulty string
+	Winner     string        // Empty if the turn cap ended the game first
+	Attempts   int           // Total turns taken by all players
+	Elapsed    time.Duration // Virtual game time: the bots' thinking times added up
+	Score      int           // Winner's score
+	Timeouts   map[string]int
+}
+
+/*
+BotStats aggregates one bot's games at one difficulty.
+*/
+type BotStats struct {
+	Name     string
+	Strategy string
+	Games    int
+	Wins     int
+	Attempts int           // Sum of the game attempts over the bot's wins
+	Elapsed  time.Duration // Sum of the virtual game times over the bot's wins
+	Timeouts int           // Turns the bot let run out
+	Scores   []int         // Winning scores, one per win
+}
+
+// WinRate returns the share of games the bot won
+func (s *BotStats) WinRate() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	return float64(s.Wins) / float64(s.Games)
+}
+
+// AverageAttempts returns the mean game length of the bot's wins
+func (s *BotStats) AverageAttempts() float64 {
+	if s.Wins == 0 {
+		return 0
+	}
+	return float64(s.Attempts) / float64(s.Wins)
+}
+
+// AverageElapsed returns the mean virtual game time of the bot's wins
+func (s *BotStats) AverageElapsed() time.Duration {
+	if s.Wins == 0 {
+		return 0
+	}
+	return s.Elapsed / time.Duration(s.Wins)
+}
+
+/*
+ScoreSummary describes a distribution of scores.
+*/
+type ScoreSummary struct {
+	Count    int
+	Mean     float64
+	StdDev   float64
+	Min, Max int
+	P10      int
+	Median   int
+	P90      int
+}
+
+/*
+SimulationReport holds a tournament's results.
+*/
+type SimulationReport struct {
+	Config     SimulationConfig
+	Players    []string                        // Bot names in seat order
+	Stats      map[string]map[string]*BotStats // Difficulty -> bot name -> stats
+	Unfinished map[string]int                  // Difficulty -> games ended by the turn cap
+	Duration   time.Duration                   // Wall-clock time the simulation took
+}
+
+/*
+runSimulation plays a tournament, spreading the games over worker goroutines.
+
+Each game gets its own random source derived from the base seed and the
+game's position, so results do not depend on the number of workers or on
+scheduling.
+
+Parameters:
+- config SimulationConfig: Tournament to play
+
+Returns:
+- *SimulationReport: Aggregated results
+- error: ErrUnknownBot for an unknown strategy
+*/
+func runSimulation(config SimulationConfig) (*SimulationReport, error) {
+	players := make([]string, 0, len(config.Strategies))
+	for _, strategy := range config.Strategies {
+		if _, err := newBot(strategy, nil); err != nil {
+			return nil, err
+		}
+		players = append(players, botName(strategy, players))
+	}
+
+	started := time.Now()
+	total := len(config.Difficulties) * config.Games
+	games := make([]SimulatedGame, total)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < max(config.Workers, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				rng := rand.New(rand.NewSource(config.Seed + int64(i)))
+				difficulty := config.Difficulties[i/config.Games]
+				games[i] = simulateGame(difficulty, players, config.Strategies, i%len(players), config.TimeLimit, rng)
+			}
+		}()
+	}
+	for i := 0; i < total; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	report := &SimulationReport{
+		Config:     config,
+		Players:    players,
+		Stats:      make(map[string]map[string]*BotStats),
+		Unfinished: make(map[string]int),
+		Duration:   time.Since(started),
+	}
+	for _, difficulty := range config.Difficulties {
+		report.Stats[difficulty] = make(map[string]*BotStats)
+		for seat, name := range players {
+			report.Stats[difficulty][name] = &BotStats{Name: name, Strategy: config.Strategies[seat]}
+		}
+	}
+	for _, game := range games {
+		for name, stats := range report.Stats[game.Difficulty] {
+			stats.Games++
+			stats.Timeouts += game.Timeouts[name]
+		}
+		if game.Winner == "" {
+			report.Unfinished[game.Difficulty]++
+			continue
+		}
+		stats := report.Stats[game.Difficulty][game.Winner]
+		stats.Wins++
+		stats.Attempts += game.Attempts
+		stats.Elapsed += game.Elapsed
+		stats.Scores = append(stats.Scores, game.Score)
+	}
+	return report, nil
+}
+
+/*
+simulateGame plays one game between bots without a terminal or real time.
+
+The game follows the same rules as a live session - evaluateGuess judges
+every guess, narrowRange tells the bots what the hints imply and
+calculateScore scores the winner - but the clock is virtual: a bot's
+thinking time is added to the game time instead of being waited out.
+
+Parameters:
+- difficulty string: Difficulty to play
+- players []string: Bot names in seat order
+- strategies []string: Strategy of each seat
+- first int: Seat that moves first
+- timeLimit time.Duration: Time allowed per guess
+- rng *rand.Rand: Source for the target and the bots
+
+Returns:
+- SimulatedGame: Outcome of the game
+*/
+func simulateGame(difficulty string, players, strategies []string, first int, timeLimit time.Duration, rng *rand.Rand) SimulatedGame {
+	gameState := &GameState{
+		Difficulty: difficulty,
+		MaxRange:   getMaxRange(difficulty),
+		TimeLimit:  timeLimit,
+	}
+	gameState.Target = rng.Intn(gameState.MaxRange) + 1
+
+	bots := make([]Bot, len(players))
+	for seat, strategy := range strategies {
+		bots[seat], _ = newBot(strategy, rng)
+	}
+
+	game := SimulatedGame{Difficulty: difficulty, Timeouts: make(map[string]int)}
+	low, high := 1, gameState.MaxRange
+	for turn := 0; turn < SimulatedTurnsPerNumber*gameState.MaxRange; turn++ {
+		seat := (first + turn) % len(players)
+		guess, think := bots[seat].NextGuess(BotView{
+			MaxRange:  gameState.MaxRange,
+			TimeLimit: timeLimit,
+			Low:       low,
+			High:      high,
+			Attempts:  game.Attempts,
+		})
+		game.Attempts++
+		if think >= timeLimit {
+			game.Elapsed += timeLimit
+			game.Timeouts[players[seat]]++
+			continue
+		}
+		game.Elapsed += think
+
+		result := evaluateGuess(gameState, strconv.Itoa(guess))
+		low, high = narrowRange(low, high, gameState.Target, result)
+		if result.Correct {
+			game.Winner = players[seat]
+			game.Score = calculateScore(game.Attempts, difficulty, game.Elapsed)
+			break
+		}
+	}
+	return game
+}
+
+/*
+summarizeScores computes the mean, spread and percentiles of a set of scores.
+*/
+func summarizeScores(scores []int) ScoreSummary {
+	if len(scores) == 0 {
+		return ScoreSummary{}
+	}
+	sorted := append([]int(nil), scores...)
+	sort.Ints(sorted)
+
+	sum := 0.0
+	for _, score := range sorted {
+		sum += float64(score)
+	}
+	mean := sum / float64(len(sorted))
+	variance := 0.0
+	for _, score := range sorted {
+		variance += (float64(score) - mean) * (float64(score) - mean)
+	}
+
+	percentile := func(p float64) int {
+		return sorted[int(p*float64(len(sorted)-1)+0.5)]
+	}
+	return ScoreSummary{
+		Count:  len(sorted),
+		Mean:   mean,
+		StdDev: math.Sqrt(variance / float64(len(sorted))),
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		P10:    percentile(0.1),
+		Median: percentile(0.5),
+		P90:    percentile(0.9),
+	}
+}
+
+/*
+displaySimulationReport prints a tournament's results: per difficulty, each
+bot's win rate, average attempts in the games it won and the distribution
+of its winning scores, followed by overall win rates.
+*/
+func displaySimulationReport(report *SimulationReport) {
+	config := report.Config
+	printColoredHeader("🤖 Bot Tournament")
+	fmt.Printf("%sBots:%s %s\n", ColorBlue, ColorReset, strings.Join(report.Players, ", "))
+	fmt.Printf("%sGames:%s %d per difficulty • seed %d • %s per guess\n",
+		ColorBlue, ColorReset, config.Games, config.Seed, config.TimeLimit)
+
+	nameWidth := len("Bot")
+	for _, name := range report.Players {
+		nameWidth = max(nameWidth, len(name))
+	}
+
+	overall := make(map[string]*BotStats)
+	for _, difficulty := range config.Difficulties {
+		fmt.Printf("\n%s %s (1-%d):%s\n", ColorCyan, strings.Title(difficulty), getMaxRange(difficulty), ColorReset)
+		fmt.Printf("  %-*s %6s %7s %9s %9s %6s %6s %6s %6s %6s\n",
+			nameWidth, "Bot", "Wins", "Win %", "Attempts", "Timeouts", "Mean", "SD", "P10", "Median", "P90")
+		for _, name := range report.Players {
+			stats := report.Stats[difficulty][name]
+			scores := summarizeScores(stats.Scores)
+			fmt.Printf("  %s%-*s%s %6d %6.1f%% %9.2f %9d %6.0f %6.0f %6d %6d %6d\n",
+				ColorBlue, nameWidth, name, ColorReset,
+				stats.Wins, stats.WinRate()*100, stats.AverageAttempts(), stats.Timeouts,
+				scores.Mean, scores.StdDev, scores.P10, scores.Median, scores.P90)
+
+			if overall[name] == nil {
+				overall[name] = &BotStats{Name: name}
+			}
+			overall[name].Games += stats.Games
+			overall[name].Wins += stats.Wins
+		}
+		if unfinished := report.Unfinished[difficulty]; unfinished > 0 {
+			printColoredMessage(fmt.Sprintf("  %d games hit the turn cap without a winner", unfinished), ColorYellow)
+		}
+	}
+
+	if len(config.Difficulties) > 1 {
+		fmt.Printf("\n%s Overall Win Rate:%s\n", ColorCyan, ColorReset)
+		labels := make([]string, len(report.Players))
+		values := make([]int, len(report.Players))
+		for i, name := range report.Players {
+			labels[i] = name
+			values[i] = overall[name].Wins
+		}
+		for i, line := range renderBarChart(labels, values, terminalWidth()-8, ColorGreen) {
+			fmt.Printf("%s %5.1f%%\n", line, overall[report.Players[i]].WinRate()*100)
+		}
+	}
+
+	fmt.Printf("\n%sSimulated %d games in %s.%s\n", ColorPurple,
+		config.Games*len(config.Difficulties), report.Duration.Round(time.Millisecond), ColorReset)
+	printSeparator()
+}
+
+/*
+runSimulateCommand plays a headless tournament between built-in bots.
+
+Usage:
+
+	guessing-game simulate [-bots binary,human,random] [-games 1000] [-difficulty all] [-seed 42] [-workers 8]
+
+Scores are "Mean", "SD" (standard deviation) and percentiles of the bot's
+winning scores. Without -seed a random seed is picked and printed so an
+interesting run can be replayed.
+
+Parameters:
+- args []string: Subcommand arguments
+
+Returns:
+- int: Process exit code
+*/
+func runSimulateCommand(args []string) int {
+	flags := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	bots := flags.String("bots", strings.Join(BotStrategies, ","), "comma-separated strategies, one bot each: "+strings.Join(BotStrategies, ", "))
+	games := flags.Int("games", DefaultSimulatedGames, "games per difficulty")
+	difficulty := flags.String("difficulty", AllDifficulties, "difficulty: easy, medium, hard or all")
+	seed := flags.Int64("seed", 0, "random seed (0 picks one)")
+	workers := flags.Int("workers", runtime.NumCPU(), "games played in parallel")
+	timeLimit := flags.Duration("time-limit", DefaultTimeLimit, "time allowed per guess")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	config := SimulationConfig{
+		Difficulties: Difficulties,
+		Games:        *games,
+		Seed:         *seed,
+		Workers:      *workers,
+		TimeLimit:    *timeLimit,
+	}
+	for _, strategy := range strings.Split(*bots, ",") {
+		if strategy = strings.ToLower(strings.TrimSpace(strategy)); strategy != "" {
+			config.Strategies = append(config.Strategies, strategy)
+		}
+	}
+	if len(config.Strategies) == 0 || len(config.Strategies) > MaxPlayers {
+		printColoredMessage(fmt.Sprintf("Give between 1 and %d bots.", MaxPlayers), ColorRed)
+		return 2
+	}
+	if *difficulty != AllDifficulties {
+		if !contains(Difficulties, *difficulty) {
+			printColoredMessage(fmt.Sprintf("Unknown difficulty %q (want easy, medium, hard or all).", *difficulty), ColorRed)
+			return 2
+		}
+		config.Difficulties = []string{*difficulty}
+	}
+	if config.Games < 1 || config.Workers < 1 || config.TimeLimit <= 0 {
+		printColoredMessage("-games, -workers and -time-limit must be positive.", ColorRed)
+		return 2
+	}
+	if config.Seed == 0 {
+		config.Seed = time.Now().UnixNano()
+	}
+
+	report, err := runSimulation(config)
+	if err != nil {
+		printColoredMessage(err.Error(), ColorRed)
+		return 2
+	}
+	displaySimulationReport(report)
+	return 0
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"gaming/my-guessing-game/protocol"
+)
+
+// SSE event names beyond the Event* match events
+const (
+	SSESnapshot = "snapshot" // Full game state sent when a stream opens
+	SSEScore    = "score"    // Final scores, sent just before game_over
+
+	SSEHeartbeat = 15 * time.Second // Comment interval keeping idle proxies open
+)
+
+/*
+apiEvent is the JSON payload of a server-sent event.
+
+Only the fields relevant to the event type are populated, mirroring
+MatchEvent. The target is only present in game_over, or in spectator
+streams of rooms whose host lets spectators see it.
+*/
+type apiEvent struct {
+	Type       string         `json:"type"`
+	Player     string         `json:"player,omitempty"`
+	Players    []string       `json:"players,omitempty"`
+	Result     *GuessView     `json:"result,omitempty"`
+	TimeLeftMS int64          `json:"time_left_ms,omitempty"`
+	Scores     map[string]int `json:"scores,omitempty"`
+	Winner     string         `json:"winner,omitempty"`
+	Target     int            `json:"target,omitempty"`
+	Attempts   int            `json:"attempts"`
+	Low        int            `json:"low,omitempty"`   // Feasible range after
+	High       int            `json:"high,omitempty"`  // a guess or timeout
+	Text       string         `json:"text,omitempty"`  // Chat message or announcement
+	Emote      string         `json:"emote,omitempty"` // Chat emote name
+}
+
+/*
+handleGameEvents streams a game's events as Server-Sent Events.
+
+Stream Format:
+  - The stream opens with a "snapshot" event carrying the full game state
+    (the same JSON as GET /api/games/{id})
+  - Every match event follows as "event: <type>" with a JSON data line;
+    types are player_joined, player_left, game_started, turn_started,
+    guess, timeout, chat, announcement, score, game_over and game_aborted
+  - The stream ends after game_over or game_aborted; streams for finished
+    games end right after the snapshot
+
+With ?spectate=1 the stream counts as a spectator; if the host enabled
+spectators_see_target, the snapshot and every guess and timeout carry the
+target. Players cannot peek at their own game: a spectator stream presenting
+a seated player's token is refused with 403, as is one from a seated
+player's IP address while the target is revealed, and streams from such an
+address never carry the target.
+
+Works with a browser EventSource or "curl -N".
+*/
+func (s *apiServer) handleGameEvents(w http.ResponseWriter, r *http.Request) {
+	room, ok := s.lookupRoom(w, r)
+	if !ok {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeAPIError(w, http.StatusInternalServerError, protocol.CodeInternal, "streaming unsupported")
+		return
+	}
+
+	// Subscribe before taking the snapshot so no event can fall in between
+	events, unsubscribe := room.Match.Subscribe()
+	defer unsubscribe()
+
+	spectating := r.URL.Query().Get("spectate") != ""
+	if spectating {
+		token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if err := room.CheckSpectator(token, r.RemoteAddr); err != nil {
+			writeMatchError(w, err)
+			return
+		}
+		defer room.Watch()()
+	}
+	spectatorTarget := func() int {
+		if !spectating {
+			return 0
+		}
+		return room.SpectatorTarget(r.RemoteAddr)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	snapshot := roomView(room)
+	if !snapshot.Finished {
+		snapshot.Target = spectatorTarget()
+	}
+	if writeSSE(w, SSESnapshot, snapshot) != nil || snapshot.Finished {
+		flusher.Flush()
+		return
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(SSEHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case event, open := <-events:
+			if !open {
+				return // Fell too far behind; the client's EventSource will reconnect
+			}
+			for _, payload := range sseEvents(event) {
+				if payload.Result != nil {
+					payload.Target = spectatorTarget() // Re-read so the host can toggle it live
+				}
+				if err := writeSSE(w, payload.Type, payload); err != nil {
+					return
+				}
+			}
+			if event.Type == EventGameOver || event.Type == EventGameAborted {
+				flusher.Flush()
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+/*
+sseEvents converts a match event into the payloads streamed to dashboards.
+
+The end of the game produces a "score" event with the final scores followed
+by "game_over" revealing the target.
+
+Parameters:
+- event MatchEvent: Event to convert
+
+Returns:
+- []apiEvent: Payloads in delivery order
+*/
+func sseEvents(event MatchEvent) []apiEvent {
+	payload := apiEvent{Type: event.Type, Player: event.Player, Players: event.Players, Attempts: event.Attempts}
+
+	switch event.Type {
+	case EventTurnStarted:
+		payload.TimeLeftMS = time.Until(event.Deadline).Milliseconds()
+	case EventGuess, EventTimeout:
+		payload.Result = &GuessView{
+			Player:   event.Player,
+			Value:    event.Result.Value,
+			Valid:    event.Result.Valid,
+			Correct:  event.Result.Correct,
+			TimedOut: event.Result.TimedOut,
+			Hint:     event.Result.Hint,
+		}
+		payload.Low, payload.High = event.Low, event.High
+	case EventChat:
+		payload.Text, payload.Emote = event.Text, event.Emote
+	case EventAnnouncement:
+		payload.Text = event.Text
+	case EventGameOver:
+		score := apiEvent{Type: SSEScore, Player: event.Player, Scores: event.Scores, Attempts: event.Attempts}
+		payload.Player = ""
+		payload.Winner = event.Player
+		payload.Target = event.Target
+		payload.Scores = event.Scores
+		return []apiEvent{score, payload}
+	}
+	return []apiEvent{payload}
+}
+
+/*
+writeSSE writes a single named event with a JSON data line.
+*/
+func writeSSE(w http.ResponseWriter, name string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+	return err
+}
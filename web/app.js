@@ -0,0 +1,402 @@
+// Browser client for the game's JSON API (see httpapi.go for the routes).
+"use strict";
+
+const state = {
+  gameId: null, // Game currently shown
+  token: null,  // Bearer token for our seat in that game
+  player: null, // Name we joined as
+  events: null, // EventSource following the game
+  spectating: false, // Watching read-only rather than playing
+  target: 0,     // Target revealed to spectators, if the host allows it
+  muted: new Set(), // Players whose chat we hide
+  turn: 0,       // Turn our next guess answers
+};
+
+// Quick emotes accepted by the server (see Emotes in chat.go)
+const EMOTES = { gg: "🤝 Good game!", lol: "😂", wow: "😮", think: "🤔", fire: "🔥", sad: "😢", clap: "👏" };
+
+const $ = (id) => document.getElementById(id);
+
+// api calls the JSON API and throws the server's error message on failure
+async function api(method, path, body) {
+  const headers = { "Content-Type": "application/json" };
+  if (state.token) headers.Authorization = "Bearer " + state.token;
+  const response = await fetch(path, {
+    method,
+    headers,
+    body: body === undefined ? undefined : JSON.stringify(body),
+  });
+  if (response.status === 204) return null;
+  const data = await response.json();
+  if (!response.ok) throw new Error(data.error || response.statusText);
+  return data;
+}
+
+function showError(err) {
+  $("error").textContent = err ? err.message || String(err) : "";
+  $("error").hidden = !err;
+}
+
+function cell(row, text) {
+  const td = document.createElement("td");
+  td.textContent = text;
+  row.appendChild(td);
+  return td;
+}
+
+function titleCase(text) {
+  return text.charAt(0).toUpperCase() + text.slice(1);
+}
+
+// Lobby
+
+async function refreshGames() {
+  if (state.gameId) return;
+  try {
+    const games = await api("GET", "/api/games");
+    const live = games.filter((game) => !game.finished);
+    const body = $("games").querySelector("tbody");
+    body.replaceChildren();
+    for (const game of live) {
+      const row = document.createElement("tr");
+      cell(row, game.id);
+      cell(row, `${titleCase(game.difficulty)} (1-${game.max_range})`);
+      cell(row, game.started ? "Playing" : `${game.players.length}/${game.capacity}`);
+      cell(row, game.host || "-");
+      cell(row, game.spectators);
+      const actions = cell(row, "");
+      if (!game.started && game.players.length < game.capacity) {
+        const join = document.createElement("button");
+        join.textContent = "Join";
+        join.onclick = () => joinGame(game.id);
+        actions.appendChild(join);
+      }
+      const watch = document.createElement("button");
+      watch.textContent = "Watch";
+      watch.onclick = () => watchGame(game.id);
+      actions.appendChild(watch);
+      body.appendChild(row);
+    }
+    $("no-games").hidden = live.length > 0;
+  } catch (err) {
+    showError(err);
+  }
+}
+
+function joinByCode(event) {
+  event.preventDefault();
+  const code = $("invite-code").value.trim().toUpperCase();
+  if (code) joinGame(code);
+}
+
+async function createGame(event) {
+  event.preventDefault();
+  const form = new FormData(event.target);
+  try {
+    const game = await api("POST", "/api/games", {
+      difficulty: form.get("difficulty"),
+      players: Number(form.get("players")),
+      time_limit_ms: Number(form.get("seconds")) * 1000,
+      spectators_see_target: form.get("reveal") === "on",
+    });
+    await joinGame(game.id);
+  } catch (err) {
+    showError(err);
+  }
+}
+
+async function joinGame(id) {
+  const name = $("player-name").value.trim();
+  if (!name) {
+    showError(new Error("Enter your name first."));
+    $("player-name").focus();
+    return;
+  }
+  try {
+    const joined = await api("POST", `/api/games/${id}/players`, { name });
+    state.gameId = id;
+    state.token = joined.token;
+    state.player = joined.player;
+    sessionStorage.setItem("session", JSON.stringify({ gameId: id, token: joined.token, player: joined.player }));
+    showError(null);
+    openGame(joined.game);
+  } catch (err) {
+    showError(err);
+  }
+}
+
+// watchGame follows a game read-only; no seat or token is involved
+async function watchGame(id) {
+  try {
+    const game = await api("GET", `/api/games/${id}`);
+    Object.assign(state, { gameId: id, token: null, player: null, spectating: true });
+    showError(null);
+    openGame(game);
+  } catch (err) {
+    showError(err);
+  }
+}
+
+// Game view
+
+function openGame(game) {
+  $("lobby").hidden = true;
+  $("game").hidden = false;
+  renderGame(game);
+
+  $("chat-log").replaceChildren();
+  $("chat-form").hidden = state.spectating;
+  $("emotes").hidden = state.spectating;
+
+  const query = state.spectating ? "?spectate=1" : "";
+  state.events = new EventSource(`/api/games/${game.id}/events${query}`);
+  state.events.addEventListener("snapshot", (e) => {
+    const snapshot = JSON.parse(e.data);
+    state.target = snapshot.target || 0;
+    renderGame(snapshot);
+  });
+  for (const type of ["player_joined", "player_left", "player_away", "player_back",
+    "game_started", "turn_started"]) {
+    state.events.addEventListener(type, refreshGame);
+  }
+  for (const type of ["guess", "timeout"]) {
+    state.events.addEventListener(type, (e) => {
+      state.target = JSON.parse(e.data).target || 0; // Only sent to spectators, if allowed
+      refreshGame();
+    });
+  }
+  state.events.addEventListener("chat", (e) => showChat(JSON.parse(e.data)));
+  state.events.addEventListener("announcement", (e) => showAnnouncement(JSON.parse(e.data)));
+  state.events.addEventListener("game_aborted", () => {
+    // The room is closed, so there is no state left to fetch
+    state.events.close();
+    $("game-status").textContent = "The game was abandoned.";
+    $("guess").disabled = true;
+  });
+  state.events.addEventListener("game_over", () => {
+    state.events.close();
+    refreshGame();
+    refreshLeaderboard();
+  });
+}
+
+async function refreshGame() {
+  try {
+    renderGame(await api("GET", `/api/games/${state.gameId}`));
+  } catch (err) {
+    showError(err);
+  }
+}
+
+function renderGame(game) {
+  $("game-title").textContent =
+    `Room ${game.id} - ${titleCase(game.difficulty)} (1-${game.max_range})`;
+  $("start").hidden = game.started || game.host !== state.player;
+  $("reveal-setting").hidden = game.finished || game.host !== state.player;
+  $("reveal").checked = game.spectators_see_target;
+  $("guess-form").hidden = state.spectating;
+
+  const status = $("game-status");
+  const myTurn = game.current_player === state.player;
+  if (myTurn) state.turn = game.turn;
+  status.className = myTurn ? "turn" : "";
+  if (game.finished && game.winner) {
+    status.textContent = `🏆 ${game.winner} wins! The number was ${game.target}. ` +
+      `Score: ${game.scores[game.winner]} points.`;
+  } else if (game.finished) {
+    status.textContent = "The game was abandoned.";
+  } else if (!game.started) {
+    status.textContent = `Share invite code ${game.id}. Waiting for players: ` +
+      `${game.players.join(", ")} (${game.players.length}/${game.capacity})`;
+  } else if (state.spectating) {
+    status.textContent = `👀 Watching: ${game.current_player}'s turn...`;
+  } else if (game.away && game.away.includes(game.current_player)) {
+    status.textContent = `${game.current_player} is disconnected; skipping their turn...`;
+  } else if (myTurn) {
+    status.textContent = `Your turn! ${Math.ceil(game.time_left_ms / 1000)}s to guess.`;
+  } else {
+    status.textContent = `${game.current_player}'s turn...`;
+  }
+
+  let range = game.started ? `Remaining range: ${game.low}-${game.high}` : "";
+  if (state.target && !game.finished) range += ` - the number is ${state.target} (hidden from players)`;
+  $("game-range").textContent = range;
+
+  $("guess").disabled = !myTurn || game.finished;
+  $("guess-form").querySelector("button").disabled = !myTurn || game.finished;
+  if (myTurn) $("guess").focus();
+
+  const log = $("guess-log");
+  log.replaceChildren();
+  for (const guess of game.guesses) {
+    const item = document.createElement("li");
+    if (guess.correct) {
+      item.className = "correct";
+      item.textContent = `${guess.player}: ${guess.value} - correct!`;
+    } else if (guess.valid) {
+      item.className = "valid";
+      item.textContent = `${guess.player}: ${guess.value} - ${guess.hint}`;
+    } else {
+      item.className = "invalid";
+      item.textContent = `${guess.player}: ${guess.timed_out ? guess.hint : "invalid guess"}`;
+    }
+    log.appendChild(item);
+  }
+}
+
+async function submitGuess(event) {
+  event.preventDefault();
+  const input = $("guess");
+  try {
+    await api("POST", `/api/games/${state.gameId}/guesses`, { guess: input.value, turn: state.turn });
+    input.value = "";
+    showError(null);
+  } catch (err) {
+    showError(err);
+  }
+}
+
+// Chat
+
+function showChat(message) {
+  if (state.muted.has(message.player)) return;
+  const item = document.createElement("li");
+  const who = document.createElement("span");
+  who.className = "who";
+  who.textContent = message.player + ": ";
+  item.append(who, message.emote ? EMOTES[message.emote] || `:${message.emote}:` : message.text);
+  $("chat-log").appendChild(item);
+  item.scrollIntoView({ block: "nearest" });
+}
+
+function showAnnouncement(message) {
+  const item = document.createElement("li");
+  item.className = "announcement";
+  item.textContent = "📢 Server: " + message.text;
+  $("chat-log").appendChild(item);
+  item.scrollIntoView({ block: "nearest" });
+}
+
+async function sendChat(event) {
+  event.preventDefault();
+  const input = $("chat-text");
+  const text = input.value.trim();
+  const [command, ...rest] = text.split(" ");
+  if (command === "/mute" || command === "/unmute") {
+    // Muting is local to this tab; the other player is not told
+    const player = rest.join(" ").trim();
+    command === "/mute" ? state.muted.add(player) : state.muted.delete(player);
+    input.value = "";
+    return;
+  }
+  try {
+    await api("POST", `/api/games/${state.gameId}/chat`, { text });
+    input.value = "";
+    showError(null);
+  } catch (err) {
+    showError(err);
+  }
+}
+
+async function sendEmote(name) {
+  try {
+    await api("POST", `/api/games/${state.gameId}/chat`, { emote: name });
+    showError(null);
+  } catch (err) {
+    showError(err);
+  }
+}
+
+async function setReveal(event) {
+  try {
+    await api("POST", `/api/games/${state.gameId}/settings`, { spectators_see_target: event.target.checked });
+    showError(null);
+  } catch (err) {
+    showError(err);
+  }
+}
+
+async function startGame() {
+  try {
+    await api("POST", `/api/games/${state.gameId}/start`);
+    showError(null);
+  } catch (err) {
+    showError(err);
+  }
+}
+
+// resumeSession reopens the game this tab was playing before a reload
+async function resumeSession() {
+  const saved = JSON.parse(sessionStorage.getItem("session") || "null");
+  if (!saved) return;
+  try {
+    const game = await api("GET", `/api/games/${saved.gameId}`);
+    Object.assign(state, saved);
+    openGame(game);
+  } catch (err) {
+    sessionStorage.removeItem("session"); // The room is gone
+  }
+}
+
+async function leaveGame() {
+  if (state.events) state.events.close();
+  try {
+    if (!state.spectating) {
+      await fetch(`/api/games/${state.gameId}/leave`, {
+        method: "POST",
+        headers: { Authorization: "Bearer " + state.token },
+      });
+    }
+  } catch (err) {
+    // The room may already be gone; leaving locally is all that matters
+  }
+  Object.assign(state, { gameId: null, token: null, player: null, events: null, spectating: false, target: 0 });
+  sessionStorage.removeItem("session");
+  $("game").hidden = true;
+  $("lobby").hidden = false;
+  refreshGames();
+}
+
+// Leaderboard
+
+async function refreshLeaderboard() {
+  try {
+    const entries = await api("GET", `/api/leaderboard?limit=10&window=${$("window").value}`);
+    const body = $("leaders");
+    body.replaceChildren();
+    entries.forEach((entry, i) => {
+      const row = document.createElement("tr");
+      cell(row, ["🥇", "🥈", "🥉"][i] || String(i + 1));
+      cell(row, entry.name);
+      cell(row, entry.wins);
+      cell(row, entry.games);
+      cell(row, entry.total_score);
+      body.appendChild(row);
+    });
+  } catch (err) {
+    showError(err);
+  }
+}
+
+$("create-form").addEventListener("submit", createGame);
+$("guess-form").addEventListener("submit", submitGuess);
+$("code-form").addEventListener("submit", joinByCode);
+$("start").addEventListener("click", startGame);
+$("reveal").addEventListener("change", setReveal);
+$("chat-form").addEventListener("submit", sendChat);
+for (const [name, shown] of Object.entries(EMOTES)) {
+  const button = document.createElement("button");
+  button.textContent = shown;
+  button.title = name;
+  button.onclick = () => sendEmote(name);
+  $("emotes").appendChild(button);
+}
+$("leave").addEventListener("click", leaveGame);
+$("window").addEventListener("change", refreshLeaderboard);
+$("player-name").value = localStorage.getItem("playerName") || "";
+$("player-name").addEventListener("change", (e) => localStorage.setItem("playerName", e.target.value.trim()));
+
+resumeSession();
+refreshGames();
+refreshLeaderboard();
+setInterval(refreshGames, 3000);
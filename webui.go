@@ -0,0 +1,29 @@
+package main
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// webFiles holds the browser UI so the binary needs no external hosting
+//
+//go:embed web
+var webFiles embed.FS
+
+/*
+webHandler serves the embedded single-page UI.
+
+The UI is plain HTML, CSS and JavaScript that talks to the JSON API and the
+Server-Sent Events stream of the same server.
+
+Returns:
+- http.Handler: File server rooted at the web directory
+*/
+func webHandler() http.Handler {
+	root, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		panic(err) // The directory is embedded at build time
+	}
+	return http.FileServerFS(root)
+}